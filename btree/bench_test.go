@@ -0,0 +1,159 @@
+package btree
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"runtime"
+	"testing"
+)
+
+// orderedMap is the method set shared by tree implementations in this package.
+type orderedMap[K, V any] interface {
+	Insert(key K, value V)
+	Get(key K) (value V, ok bool)
+	Remove(key K) (removed bool)
+	All() iter.Seq2[K, V]
+}
+
+var benchSizes = []int{1_000, 100_000, 1_000_000}
+
+func benchTrees() []struct {
+	name string
+	new  func() orderedMap[int, int]
+} {
+	return []struct {
+		name string
+		new  func() orderedMap[int, int]
+	}{
+		{"LLBTree", func() orderedMap[int, int] { return NewLLBTreeOrdered[int, int]() }},
+		{"LLBTree-slab", func() orderedMap[int, int] { return NewLLBTreeOrdered[int, int](SetSlabSize(256)) }},
+		{"LLBTree-reuse", func() orderedMap[int, int] { return NewLLBTreeOrdered[int, int](SetNodeReuse(true)) }},
+		{"BTree-2", func() orderedMap[int, int] { return NewBTreeOrdered[int, int](2) }},
+		{"BTree-16", func() orderedMap[int, int] { return NewBTreeOrdered[int, int](16) }},
+		{"BTree-64", func() orderedMap[int, int] { return NewBTreeOrdered[int, int](64) }},
+	}
+}
+
+func benchKeys(n int) []int {
+	r := rand.New(rand.NewPCG(1, 2))
+	return r.Perm(n)
+}
+
+func BenchmarkInsert(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		for _, tree := range benchTrees() {
+			b.Run(fmt.Sprintf("%s/n=%d", tree.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				var m orderedMap[int, int]
+				for i := range b.N {
+					if i%n == 0 {
+						b.StopTimer()
+						m = tree.new()
+						b.StartTimer()
+					}
+					k := keys[i%n]
+					m.Insert(k, k)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		for _, tree := range benchTrees() {
+			m := tree.new()
+			for _, k := range keys {
+				m.Insert(k, k)
+			}
+			b.Run(fmt.Sprintf("%s/n=%d", tree.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := range b.N {
+					if _, ok := m.Get(keys[i%n]); !ok {
+						b.Fatal("not found")
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRemoveInsert(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		for _, tree := range benchTrees() {
+			m := tree.new()
+			for _, k := range keys {
+				m.Insert(k, k)
+			}
+			b.Run(fmt.Sprintf("%s/n=%d", tree.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := range b.N {
+					k := keys[i%n]
+					m.Remove(k)
+					m.Insert(k, k)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		for _, tree := range benchTrees() {
+			m := tree.new()
+			for _, k := range keys {
+				m.Insert(k, k)
+			}
+			b.Run(fmt.Sprintf("%s/n=%d", tree.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					for range m.All() {
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkChurn removes the oldest element and inserts a new one in each iteration,
+// reporting GC cost along with allocations.
+func BenchmarkChurn(b *testing.B) {
+	for _, n := range benchSizes {
+		for _, tree := range []struct {
+			name    string
+			options []LLBTreeOption
+		}{
+			{"default", nil},
+			{"slab", []LLBTreeOption{SetSlabSize(256)}},
+			{"reuse", []LLBTreeOption{SetNodeReuse(true)}},
+			{"slab-reuse", []LLBTreeOption{SetSlabSize(256), SetNodeReuse(true)}},
+		} {
+			b.Run(fmt.Sprintf("%s/n=%d", tree.name, n), func(b *testing.B) {
+				m := NewLLBTreeOrdered[int, [4]int](tree.options...)
+				for i := range n {
+					m.Insert(i, [4]int{i})
+				}
+				runtime.GC()
+
+				var before, after runtime.MemStats
+				runtime.ReadMemStats(&before)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					m.Remove(i)
+					m.Insert(n+i, [4]int{i})
+				}
+				b.StopTimer()
+				runtime.ReadMemStats(&after)
+
+				b.ReportMetric(float64(after.PauseTotalNs-before.PauseTotalNs)/float64(b.N), "gc-pause-ns/op")
+				b.ReportMetric(float64(after.NumGC-before.NumGC), "gcs")
+			})
+		}
+	}
+}
@@ -0,0 +1,569 @@
+package btree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// BTree is a B-tree.
+//
+// Every node except the root holds degree-1 to 2*degree-1 elements in a contiguous slice.
+// Compared to [LLBTree], which allocates one node per element,
+// BTree touches far fewer cache lines per lookup,
+// at the cost of shifting elements within a node on update.
+// Larger degree makes the tree shallower and wider.
+type BTree[K, V any] struct {
+	cmp    func(l, r K) int
+	degree int
+	root   *btreeNode[K, V]
+	len    int
+	// gen is incremented on every mutation
+	// so that iterators can notice the tree has been restructured under them.
+	gen uint64
+}
+
+type btreeItem[K, V any] struct {
+	key   K
+	value V
+}
+
+type btreeNode[K, V any] struct {
+	items    []btreeItem[K, V]
+	children []*btreeNode[K, V] // nil for leaves, otherwise len(children) == len(items)+1.
+}
+
+// NewBTree returns a new BTree.
+// NewBTree panics if degree is less than 2.
+func NewBTree[K, V any](degree int, cmp func(l, r K) int) *BTree[K, V] {
+	if degree < 2 {
+		panic("btree: degree must be greater than or equal to 2")
+	}
+	return &BTree[K, V]{
+		cmp:    cmp,
+		degree: degree,
+	}
+}
+
+func NewBTreeOrdered[K cmp.Ordered, V any](degree int) *BTree[K, V] {
+	return NewBTree[K, V](degree, cmp.Compare[K])
+}
+
+func (t *BTree[K, V]) Insert(key K, value V) {
+	t.gen++
+	item := btreeItem[K, V]{key, value}
+	if t.root == nil {
+		t.root = &btreeNode[K, V]{items: []btreeItem[K, V]{item}}
+		t.len++
+		return
+	}
+	if len(t.root.items) >= t.maxItems() {
+		root := &btreeNode[K, V]{children: []*btreeNode[K, V]{t.root}}
+		t.splitChild(root, 0)
+		t.root = root
+	}
+	if t.insertNonFull(t.root, item) {
+		t.len++
+	}
+}
+
+func (t *BTree[K, V]) InsertSeq(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		t.Insert(k, v)
+	}
+}
+
+func (t *BTree[K, V]) Get(key K) (value V, ok bool) {
+	n := t.root
+	for n != nil {
+		i, found := t.find(n, key)
+		if found {
+			return n.items[i].value, true
+		}
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return
+}
+
+func (t *BTree[K, V]) Remove(key K) (removed bool) {
+	if t.root == nil {
+		return false
+	}
+	_, removed = t.remove(t.root, removeItem, key)
+	if len(t.root.items) == 0 {
+		if t.root.isLeaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	// Even if key is absent, remove may have borrowed from or merged nodes on its way down,
+	// or the root may have collapsed. Iterators must reseek in either case.
+	t.gen++
+	if removed {
+		t.len--
+	}
+	return removed
+}
+
+// Len returns the number of elements in t.
+func (t *BTree[K, V]) Len() int {
+	return t.len
+}
+
+func (t *BTree[K, V]) Min() (key K, value V, ok bool) {
+	n := t.root
+	if n == nil {
+		return
+	}
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n.items[0].key, n.items[0].value, true
+}
+
+func (t *BTree[K, V]) Max() (key K, value V, ok bool) {
+	n := t.root
+	if n == nil {
+		return
+	}
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+	item := n.items[len(n.items)-1]
+	return item.key, item.value, true
+}
+
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return t.forward((*btreeIter[K, V]).first, false, *new(K))
+}
+
+func (t *BTree[K, V]) Backward() iter.Seq2[K, V] {
+	return t.backward((*btreeIter[K, V]).last, false, *new(K))
+}
+
+// Scan is like [LLBTree.Scan]:
+// it returns an iterator over key-value pairs between lo and hi, both inclusive.
+// If lo is greater than hi, pairs are yielded in descending order.
+func (t *BTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
+	if t.cmp(lo, hi) <= 0 {
+		return t.forward(
+			func(it *btreeIter[K, V]) bool { return it.seekGE(lo) },
+			true,
+			hi,
+		)
+	}
+	return t.backward(
+		func(it *btreeIter[K, V]) bool { return it.seekLE(lo) },
+		true,
+		hi,
+	)
+}
+
+func (t *BTree[K, V]) forward(start func(it *btreeIter[K, V]) bool, limit bool, limitKey K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := &btreeIter[K, V]{t: t}
+		if !start(it) {
+			return
+		}
+		for {
+			item := it.item()
+			if limit && t.cmp(item.key, limitKey) > 0 {
+				return
+			}
+			gen := t.gen
+			if !yield(item.key, item.value) {
+				return
+			}
+			var ok bool
+			if gen != t.gen {
+				ok = it.seekGT(item.key)
+			} else {
+				ok = it.next()
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (t *BTree[K, V]) backward(start func(it *btreeIter[K, V]) bool, limit bool, limitKey K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := &btreeIter[K, V]{t: t}
+		if !start(it) {
+			return
+		}
+		for {
+			item := it.item()
+			if limit && t.cmp(item.key, limitKey) < 0 {
+				return
+			}
+			gen := t.gen
+			if !yield(item.key, item.value) {
+				return
+			}
+			var ok bool
+			if gen != t.gen {
+				ok = it.seekLT(item.key)
+			} else {
+				ok = it.prev()
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (t *BTree[K, V]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+func (t *BTree[K, V]) minItems() int {
+	return t.degree - 1
+}
+
+// find returns the index of the first item in n whose key is greater than or equal to key.
+func (t *BTree[K, V]) find(n *btreeNode[K, V], key K) (i int, found bool) {
+	lo, hi := 0, len(n.items)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.cmp(n.items[mid].key, key) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(n.items) && t.cmp(n.items[lo].key, key) == 0
+}
+
+// splitChild splits full n.children[i] into two, moving its median item up into n.
+func (t *BTree[K, V]) splitChild(n *btreeNode[K, V], i int) {
+	child := n.children[i]
+	mid := t.degree - 1
+
+	right := &btreeNode[K, V]{
+		items: append(make([]btreeItem[K, V], 0, t.maxItems()), child.items[mid+1:]...),
+	}
+	if !child.isLeaf() {
+		right.children = append(make([]*btreeNode[K, V], 0, t.maxItems()+1), child.children[mid+1:]...)
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+	median := child.items[mid]
+	clear(child.items[mid:])
+	child.items = child.items[:mid]
+
+	n.items = insertAt(n.items, i, median)
+	n.children = insertAt(n.children, i+1, right)
+}
+
+// insertNonFull inserts item into the subtree rooted at n, which must not be full.
+// It reports whether a new item is added rather than an existing one being overwritten.
+func (t *BTree[K, V]) insertNonFull(n *btreeNode[K, V], item btreeItem[K, V]) bool {
+	for {
+		i, found := t.find(n, item.key)
+		if found {
+			n.items[i].value = item.value
+			return false
+		}
+		if n.isLeaf() {
+			n.items = insertAt(n.items, i, item)
+			return true
+		}
+		if len(n.children[i].items) >= t.maxItems() {
+			t.splitChild(n, i)
+			switch c := t.cmp(item.key, n.items[i].key); {
+			case c == 0:
+				n.items[i].value = item.value
+				return false
+			case c > 0:
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+type removeType int
+
+const (
+	removeItem removeType = iota
+	removeMin
+	removeMax
+)
+
+// remove removes an item from the subtree rooted at n.
+// Before descending into a child, remove ensures the child has more than minItems items
+// so that removal never leaves a node underflowed.
+func (t *BTree[K, V]) remove(n *btreeNode[K, V], typ removeType, key K) (removed btreeItem[K, V], ok bool) {
+	for {
+		var (
+			i     int
+			found bool
+		)
+		switch typ {
+		case removeMin:
+			if n.isLeaf() {
+				removed = n.items[0]
+				n.items = removeAt(n.items, 0)
+				return removed, true
+			}
+			i = 0
+		case removeMax:
+			if n.isLeaf() {
+				removed = n.items[len(n.items)-1]
+				n.items = removeAt(n.items, len(n.items)-1)
+				return removed, true
+			}
+			i = len(n.items)
+		default:
+			i, found = t.find(n, key)
+			if n.isLeaf() {
+				if !found {
+					return
+				}
+				removed = n.items[i]
+				n.items = removeAt(n.items, i)
+				return removed, true
+			}
+		}
+
+		if len(n.children[i].items) <= t.minItems() {
+			// The tree may have been reshaped; search n again.
+			t.growChild(n, i)
+			continue
+		}
+
+		if found {
+			removed = n.items[i]
+			n.items[i], _ = t.remove(n.children[i], removeMax, key)
+			return removed, true
+		}
+		n = n.children[i]
+	}
+}
+
+// growChild makes n.children[i] have more than minItems items,
+// by either borrowing an item from its sibling or merging it with a sibling.
+func (t *BTree[K, V]) growChild(n *btreeNode[K, V], i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > t.minItems():
+		// borrow from left.
+		child, left := n.children[i], n.children[i-1]
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = removeAt(left.items, len(left.items)-1)
+		if !left.isLeaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = removeAt(left.children, len(left.children)-1)
+		}
+	case i < len(n.items) && len(n.children[i+1].items) > t.minItems():
+		// borrow from right.
+		child, right := n.children[i], n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+		if !right.isLeaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+	default:
+		// merge with right, or left if child is the rightmost.
+		if i >= len(n.items) {
+			i--
+		}
+		child, right := n.children[i], n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		child.items = append(child.items, right.items...)
+		child.children = append(child.children, right.children...)
+		n.items = removeAt(n.items, i)
+		n.children = removeAt(n.children, i+1)
+	}
+}
+
+func (n *btreeNode[K, V]) isLeaf() bool {
+	return len(n.children) == 0
+}
+
+func insertAt[S ~[]E, E any](s S, i int, e E) S {
+	var zero E
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = e
+	return s
+}
+
+func removeAt[S ~[]E, E any](s S, i int) S {
+	copy(s[i:], s[i+1:])
+	var zero E
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+type btreeFrame[K, V any] struct {
+	n *btreeNode[K, V]
+	i int
+}
+
+// btreeIter points at an item in BTree.
+//
+// The top frame of stack points at the current item, n.items[i].
+// Other frames are ancestors; a frame (n, i) means the iterator is under n.children[i].
+type btreeIter[K, V any] struct {
+	t     *BTree[K, V]
+	stack []btreeFrame[K, V]
+}
+
+func (it *btreeIter[K, V]) item() btreeItem[K, V] {
+	top := it.stack[len(it.stack)-1]
+	return top.n.items[top.i]
+}
+
+func (it *btreeIter[K, V]) push(n *btreeNode[K, V], i int) {
+	it.stack = append(it.stack, btreeFrame[K, V]{n, i})
+}
+
+func (it *btreeIter[K, V]) top() *btreeFrame[K, V] {
+	return &it.stack[len(it.stack)-1]
+}
+
+func (it *btreeIter[K, V]) descendLeft(n *btreeNode[K, V]) {
+	for !n.isLeaf() {
+		it.push(n, 0)
+		n = n.children[0]
+	}
+	it.push(n, 0)
+}
+
+func (it *btreeIter[K, V]) descendRight(n *btreeNode[K, V]) {
+	for !n.isLeaf() {
+		it.push(n, len(n.items))
+		n = n.children[len(n.items)]
+	}
+	it.push(n, len(n.items)-1)
+}
+
+func (it *btreeIter[K, V]) first() bool {
+	it.stack = it.stack[:0]
+	if it.t.root == nil {
+		return false
+	}
+	it.descendLeft(it.t.root)
+	return true
+}
+
+func (it *btreeIter[K, V]) last() bool {
+	it.stack = it.stack[:0]
+	if it.t.root == nil {
+		return false
+	}
+	it.descendRight(it.t.root)
+	return true
+}
+
+func (it *btreeIter[K, V]) next() bool {
+	top := it.top()
+	if !top.n.isLeaf() {
+		top.i++
+		it.descendLeft(top.n.children[top.i])
+		return true
+	}
+	top.i++
+	for top.i >= len(top.n.items) {
+		it.stack = it.stack[:len(it.stack)-1]
+		if len(it.stack) == 0 {
+			return false
+		}
+		top = it.top()
+	}
+	return true
+}
+
+func (it *btreeIter[K, V]) prev() bool {
+	top := it.top()
+	if !top.n.isLeaf() {
+		it.descendRight(top.n.children[top.i])
+		return true
+	}
+	top.i--
+	for top.i < 0 {
+		it.stack = it.stack[:len(it.stack)-1]
+		if len(it.stack) == 0 {
+			return false
+		}
+		top = it.top()
+		top.i--
+	}
+	return true
+}
+
+// seek moves it to the item with key, or the leaf position where key would be inserted.
+func (it *btreeIter[K, V]) seek(key K) (found bool) {
+	it.stack = it.stack[:0]
+	n := it.t.root
+	for n != nil {
+		i, found := it.t.find(n, key)
+		it.push(n, i)
+		if found {
+			return true
+		}
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return false
+}
+
+// seekGE moves it to the least item whose key is greater than or equal to key.
+func (it *btreeIter[K, V]) seekGE(key K) bool {
+	if it.seek(key) {
+		return true
+	}
+	// The top frame points at where key would be inserted, which is the item next to key if any.
+	for len(it.stack) > 0 && it.top().i >= len(it.top().n.items) {
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return len(it.stack) > 0
+}
+
+// seekGT moves it to the least item whose key is greater than key.
+func (it *btreeIter[K, V]) seekGT(key K) bool {
+	if it.seek(key) {
+		return it.next()
+	}
+	return it.seekGE(key)
+}
+
+// seekLE moves it to the greatest item whose key is less than or equal to key.
+func (it *btreeIter[K, V]) seekLE(key K) bool {
+	if it.seek(key) {
+		return true
+	}
+	return it.stepBack()
+}
+
+// seekLT moves it to the greatest item whose key is less than key.
+func (it *btreeIter[K, V]) seekLT(key K) bool {
+	if it.seek(key) {
+		return it.prev()
+	}
+	return it.stepBack()
+}
+
+// stepBack moves it from an insertion point left by seek to the item just before it.
+func (it *btreeIter[K, V]) stepBack() bool {
+	for len(it.stack) > 0 {
+		top := it.top()
+		top.i--
+		if top.i >= 0 {
+			return true
+		}
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return false
+}
@@ -0,0 +1,281 @@
+package btree
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// assertBTree checks that every leaf is at the same depth,
+// every node holds a valid number of items and keys are sorted.
+func assertBTree[K, V any](t *testing.T, tree *BTree[K, V]) {
+	t.Helper()
+	if tree.root == nil {
+		if tree.len != 0 {
+			t.Fatalf("empty tree with len = %d", tree.len)
+		}
+		return
+	}
+	leafDepth := -1
+	var count int
+	var walk func(n *btreeNode[K, V], depth int)
+	walk = func(n *btreeNode[K, V], depth int) {
+		count += len(n.items)
+		if n != tree.root && len(n.items) < tree.minItems() {
+			t.Fatalf("underflow: %d items", len(n.items))
+		}
+		if len(n.items) > tree.maxItems() || len(n.items) == 0 {
+			t.Fatalf("wrong number of items: %d", len(n.items))
+		}
+		for i := 1; i < len(n.items); i++ {
+			if tree.cmp(n.items[i-1].key, n.items[i].key) >= 0 {
+				t.Fatalf("not sorted: %v, %v", n.items[i-1].key, n.items[i].key)
+			}
+		}
+		if n.isLeaf() {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("leaves at different depth: %d, %d", leafDepth, depth)
+			}
+			return
+		}
+		if len(n.children) != len(n.items)+1 {
+			t.Fatalf("wrong number of children: items = %d, children = %d", len(n.items), len(n.children))
+		}
+		for _, c := range n.children {
+			walk(c, depth+1)
+		}
+	}
+	walk(tree.root, 0)
+	if count != tree.len {
+		t.Fatalf("wrong len: cached = %d, actual = %d", tree.len, count)
+	}
+}
+
+func TestBTree(t *testing.T) {
+	for _, degree := range []int{2, 3} {
+		t.Run(fmt.Sprintf("degree=%d", degree), func(t *testing.T) {
+			runInAllPattern2(
+				t,
+				llbTreeExpected,
+				func(t *testing.T, pattern []keyValue[int, string]) {
+					tree := NewBTreeOrdered[int, string](degree)
+					tree.InsertSeq(values2(pattern))
+					assertBTree(t, tree)
+
+					collected := collect2(t, func(i int) int { return i }, tree.All())
+					if !slices.Equal(llbTreeExpected, collected) {
+						t.Fatalf("not as expected = %#v", collected)
+					}
+
+					expected := slices.Clone(llbTreeExpected)
+					slices.Reverse(expected)
+					collected = collect2(t, func(i int) int { return i }, tree.Backward())
+					if !slices.Equal(expected, collected) {
+						t.Fatalf("not as expected = %#v", collected)
+					}
+
+					if k, _, ok := tree.Min(); !ok || k != 0 {
+						t.Fatalf("wrong min: %d", k)
+					}
+					if k, _, ok := tree.Max(); !ok || k != 7 {
+						t.Fatalf("wrong max: %d", k)
+					}
+
+					for _, tc := range []struct {
+						lo, hi   int
+						expected []keyValue[int, string]
+					}{
+						{3, 4, []keyValue[int, string]{{4, "baz"}}},
+						{2, 3, []keyValue[int, string]{{2, "bar"}}},
+						{3, 3, nil},
+						{-1, 0, []keyValue[int, string]{{0, "foo"}}},
+						{8, 9, nil},
+						{2, 5, []keyValue[int, string]{{2, "bar"}, {4, "baz"}, {5, "qux"}}},
+						{5, 1, []keyValue[int, string]{{5, "qux"}, {4, "baz"}, {2, "bar"}}},
+						{6, 3, []keyValue[int, string]{{5, "qux"}, {4, "baz"}}},
+						{9, 7, []keyValue[int, string]{{7, "quux"}}},
+					} {
+						collected := collect2(t, func(i int) int { return i }, tree.Scan(tc.lo, tc.hi))
+						if !slices.Equal(tc.expected, collected) {
+							t.Errorf("Scan(%d, %d): not equal:\nexpected: %#v\nactual  : %#v", tc.lo, tc.hi, tc.expected, collected)
+						}
+					}
+
+					for _, kv := range pattern {
+						if !tree.Remove(kv.K) {
+							t.Fatalf("tried to Remove %d, but Remove returned false", kv.K)
+						}
+						assertBTree(t, tree)
+					}
+					if tree.Len() != 0 {
+						t.Fatalf("must be empty but len = %d", tree.Len())
+					}
+				},
+			)
+		})
+	}
+}
+
+func TestBTree_remove_while_iteration(t *testing.T) {
+	for _, degree := range []int{2, 3} {
+		tree := NewBTreeOrdered[int, int](degree)
+		for i := range 100 {
+			tree.Insert(i, i)
+		}
+
+		var collected []int
+		for k := range tree.All() {
+			if k%3 == 0 {
+				tree.Remove(k + 1)
+				tree.Remove(k)
+			}
+			collected = append(collected, k)
+		}
+		var expected []int
+		for i := range 100 {
+			if i%3 != 1 {
+				expected = append(expected, i)
+			}
+		}
+		if !slices.Equal(expected, collected) {
+			t.Fatalf("not equal:\nexpected: %v\nactual  : %v", expected, collected)
+		}
+
+		collected = collected[:0]
+		for k := range tree.Backward() {
+			tree.Remove(k)
+			collected = append(collected, k)
+		}
+		expected = slices.DeleteFunc(expected, func(i int) bool { return i%3 == 0 })
+		slices.Reverse(expected)
+		if !slices.Equal(expected, collected) {
+			t.Fatalf("not equal:\nexpected: %v\nactual  : %v", expected, collected)
+		}
+		assertBTree(t, tree)
+	}
+}
+
+func TestBTree_mutation_without_removal_while_iteration(t *testing.T) {
+	type testCase struct {
+		name     string
+		n        int
+		forward  func(tree *BTree[int, int], k int)
+		backward func(tree *BTree[int, int], k int)
+	}
+	for _, tc := range []testCase{
+		{
+			// Removing missing keys still borrows from or merges nodes.
+			name:     "remove missing",
+			n:        8,
+			forward:  func(tree *BTree[int, int], k int) { tree.Remove(k + 1) },
+			backward: func(tree *BTree[int, int], k int) { tree.Remove(k - 1) },
+		},
+		{
+			name:     "remove missing",
+			n:        100,
+			forward:  func(tree *BTree[int, int], k int) { tree.Remove(k + 1) },
+			backward: func(tree *BTree[int, int], k int) { tree.Remove(k - 1) },
+		},
+		{
+			// Inserting keys behind the cursor splits nodes.
+			name:     "insert behind",
+			n:        100,
+			forward:  func(tree *BTree[int, int], k int) { tree.Insert(-k-1, -k-1) },
+			backward: func(tree *BTree[int, int], k int) { tree.Insert(k+1000, k+1000) },
+		},
+	} {
+		for _, degree := range []int{2, 3} {
+			t.Run(fmt.Sprintf("%s/n=%d/degree=%d", tc.name, tc.n, degree), func(t *testing.T) {
+				tree := NewBTreeOrdered[int, int](degree)
+				var expected []int
+				for i := range tc.n {
+					tree.Insert(i*2, i*2)
+					expected = append(expected, i*2)
+				}
+
+				var collected []int
+				for k := range tree.Scan(0, tc.n*2) {
+					tc.forward(tree, k)
+					collected = append(collected, k)
+				}
+				if !slices.Equal(expected, collected) {
+					t.Fatalf("not equal:\nexpected: %v\nactual  : %v", expected, collected)
+				}
+				assertBTree(t, tree)
+
+				collected = collected[:0]
+				for k := range tree.Backward() {
+					if k >= tc.n*2 {
+						continue
+					}
+					if k < 0 {
+						break
+					}
+					tc.backward(tree, k)
+					collected = append(collected, k)
+				}
+				slices.Reverse(expected)
+				if !slices.Equal(expected, collected) {
+					t.Fatalf("not equal:\nexpected: %v\nactual  : %v", expected, collected)
+				}
+				assertBTree(t, tree)
+			})
+		}
+	}
+}
+
+func TestBTree_random(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		t.Run(fmt.Sprintf("degree=%d", degree), func(t *testing.T) {
+			r := rand.New(rand.NewPCG(uint64(degree), 31))
+			tree := NewBTreeOrdered[int, int](degree)
+			model := map[int]int{}
+			for i := range 10000 {
+				k := r.IntN(1000)
+				if r.IntN(2) == 0 {
+					removed := tree.Remove(k)
+					_, ok := model[k]
+					if removed != ok {
+						t.Fatalf("Remove(%d) = %t, want %t", k, removed, ok)
+					}
+					delete(model, k)
+				} else {
+					tree.Insert(k, i)
+					model[k] = i
+				}
+				if i%100 == 0 {
+					assertBTree(t, tree)
+				}
+			}
+			assertBTree(t, tree)
+
+			keys := slices.Sorted(maps.Keys(model))
+			if collected := slices.Collect(omitL(tree.All())); !slices.Equal(keys, collected) {
+				t.Fatalf("not equal:\nexpected: %v\nactual  : %v", keys, collected)
+			}
+			for _, k := range keys {
+				if v, ok := tree.Get(k); !ok || v != model[k] {
+					t.Fatalf("Get(%d) = (%d, %t), want (%d, true)", k, v, ok, model[k])
+				}
+			}
+			lo, hi := 250, 750
+			var expected []int
+			for _, k := range keys {
+				if lo <= k && k <= hi {
+					expected = append(expected, k)
+				}
+			}
+			if collected := slices.Collect(omitL(tree.Scan(lo, hi))); !slices.Equal(expected, collected) {
+				t.Fatalf("not equal:\nexpected: %v\nactual  : %v", expected, collected)
+			}
+			slices.Reverse(expected)
+			if collected := slices.Collect(omitL(tree.Scan(hi, lo))); !slices.Equal(expected, collected) {
+				t.Fatalf("not equal:\nexpected: %v\nactual  : %v", expected, collected)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Package btreetest implements a randomized, model-based checker for ordered map implementations.
+//
+// [TestOrderedMap] drives an implementation and a reference model, a sorted slice,
+// with the same random sequence of operations
+// and reports the first point where their observable behavior diverges.
+// It works with any type satisfying [OrderedMap],
+// including trees in package btree and types wrapping them.
+package btreetest
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// OrderedMap is the method set exercised by [TestOrderedMap].
+//
+// Scan(lo, hi) must yield pairs whose keys are between lo and hi, both inclusive,
+// in ascending order if lo <= hi, otherwise in descending order.
+type OrderedMap[K, V any] interface {
+	Insert(key K, value V)
+	Get(key K) (value V, ok bool)
+	Remove(key K) (removed bool)
+	Len() int
+	Min() (key K, value V, ok bool)
+	Max() (key K, value V, ok bool)
+	All() iter.Seq2[K, V]
+	Backward() iter.Seq2[K, V]
+	Scan(lo, hi K) iter.Seq2[K, V]
+}
+
+// Validator is implemented by maps which can check their own internal invariants.
+// If the map passed to [TestOrderedMap] implements Validator,
+// Validate is called along with every full comparison against the model.
+type Validator interface {
+	Validate() error
+}
+
+// Config configures [TestOrderedMap].
+type Config[K, V any] struct {
+	// Cmp is the comparator the map under test orders keys with. Cmp must not be nil.
+	Cmp func(l, r K) int
+	// Key generates a random key. Key must not be nil.
+	// A narrow key space makes collisions, thus overwrites and successful removals, more frequent.
+	Key func(r *rand.Rand) K
+	// Value generates a random value. Value must not be nil.
+	Value func(r *rand.Rand) V
+	// Equal reports whether two values are equal.
+	// If nil, values are compared by ==, which panics if V is not comparable.
+	Equal func(l, r V) bool
+	// Seed seeds the random operation sequence.
+	Seed uint64
+	// Ops is the number of operations to perform. If zero, 10000 is used.
+	Ops int
+	// FullCheckInterval is the number of operations between full comparisons,
+	// where all elements are compared against the model and Validate is called.
+	// If zero, 100 is used.
+	FullCheckInterval int
+}
+
+// IntConfig returns a Config for maps from int to int
+// where keys are drawn from [0, keySpace).
+func IntConfig(keySpace int, seed uint64) Config[int, int] {
+	return Config[int, int]{
+		Cmp:   cmp.Compare[int],
+		Key:   func(r *rand.Rand) int { return r.IntN(keySpace) },
+		Value: func(r *rand.Rand) int { return r.Int() },
+		Seed:  seed,
+	}
+}
+
+// TestOrderedMap performs a random sequence of operations on m, which must be empty,
+// checking every result against the model.
+// It returns an error describing the first divergence, or nil if none is found.
+func TestOrderedMap[K, V any](m OrderedMap[K, V], cfg Config[K, V]) error {
+	if cfg.Ops == 0 {
+		cfg.Ops = 10000
+	}
+	if cfg.FullCheckInterval == 0 {
+		cfg.FullCheckInterval = 100
+	}
+	if cfg.Equal == nil {
+		cfg.Equal = func(l, r V) bool { return any(l) == any(r) }
+	}
+
+	c := &checker[K, V]{
+		m:   m,
+		cfg: cfg,
+		r:   rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15)),
+	}
+
+	if err := c.fullCheck(); err != nil {
+		return fmt.Errorf("initial state: %w", err)
+	}
+	for step := range cfg.Ops {
+		if err := c.step(); err != nil {
+			return fmt.Errorf("step %d: %w", step, err)
+		}
+		if (step+1)%cfg.FullCheckInterval == 0 || step == cfg.Ops-1 {
+			if err := c.fullCheck(); err != nil {
+				return fmt.Errorf("step %d: %w", step, err)
+			}
+		}
+	}
+	return nil
+}
+
+type entry[K, V any] struct {
+	key   K
+	value V
+}
+
+type checker[K, V any] struct {
+	m     OrderedMap[K, V]
+	cfg   Config[K, V]
+	r     *rand.Rand
+	model []entry[K, V]
+}
+
+func (c *checker[K, V]) find(key K) (int, bool) {
+	return slices.BinarySearchFunc(c.model, key, func(e entry[K, V], k K) int { return c.cfg.Cmp(e.key, k) })
+}
+
+func (c *checker[K, V]) step() error {
+	switch op := c.r.IntN(10); {
+	case op < 4:
+		k, v := c.cfg.Key(c.r), c.cfg.Value(c.r)
+		c.m.Insert(k, v)
+		i, found := c.find(k)
+		if found {
+			c.model[i].value = v
+		} else {
+			c.model = slices.Insert(c.model, i, entry[K, V]{k, v})
+		}
+		if l := c.m.Len(); l != len(c.model) {
+			return fmt.Errorf("Insert(%v, %v): Len() = %d, want %d", k, v, l, len(c.model))
+		}
+	case op < 7:
+		k := c.cfg.Key(c.r)
+		removed := c.m.Remove(k)
+		i, found := c.find(k)
+		if found {
+			c.model = slices.Delete(c.model, i, i+1)
+		}
+		if removed != found {
+			return fmt.Errorf("Remove(%v) = %t, want %t", k, removed, found)
+		}
+		if l := c.m.Len(); l != len(c.model) {
+			return fmt.Errorf("Remove(%v): Len() = %d, want %d", k, l, len(c.model))
+		}
+	case op < 9:
+		k := c.cfg.Key(c.r)
+		v, ok := c.m.Get(k)
+		i, found := c.find(k)
+		if ok != found || (found && !c.cfg.Equal(v, c.model[i].value)) {
+			var want V
+			if found {
+				want = c.model[i].value
+			}
+			return fmt.Errorf("Get(%v) = (%v, %t), want (%v, %t)", k, v, ok, want, found)
+		}
+	default:
+		lo, hi := c.cfg.Key(c.r), c.cfg.Key(c.r)
+		if err := c.checkScan(lo, hi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker[K, V]) fullCheck() error {
+	if l := c.m.Len(); l != len(c.model) {
+		return fmt.Errorf("Len() = %d, want %d", l, len(c.model))
+	}
+	if err := c.compareSeq("All()", c.m.All(), slices.Values(c.model)); err != nil {
+		return err
+	}
+	if err := c.compareSeq("Backward()", c.m.Backward(), backward(c.model)); err != nil {
+		return err
+	}
+	for _, m := range []struct {
+		name string
+		fn   func() (K, V, bool)
+		idx  int
+	}{
+		{"Min()", c.m.Min, 0},
+		{"Max()", c.m.Max, len(c.model) - 1},
+	} {
+		k, v, ok := m.fn()
+		if len(c.model) == 0 {
+			if ok {
+				return fmt.Errorf("%s = (%v, %v, true) for empty map", m.name, k, v)
+			}
+			continue
+		}
+		want := c.model[m.idx]
+		if !ok || c.cfg.Cmp(k, want.key) != 0 || !c.cfg.Equal(v, want.value) {
+			return fmt.Errorf("%s = (%v, %v, %t), want (%v, %v, true)", m.name, k, v, ok, want.key, want.value)
+		}
+	}
+	if v, ok := c.m.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("Validate(): %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *checker[K, V]) checkScan(lo, hi K) error {
+	var expected iter.Seq[entry[K, V]]
+	if c.cfg.Cmp(lo, hi) <= 0 {
+		i, _ := c.find(lo)
+		j, found := c.find(hi)
+		if found {
+			j++
+		}
+		expected = slices.Values(c.model[i:j])
+	} else {
+		i, found := c.find(lo)
+		if found {
+			i++
+		}
+		j, _ := c.find(hi)
+		expected = backward(c.model[j:i])
+	}
+	return c.compareSeq(fmt.Sprintf("Scan(%v, %v)", lo, hi), c.m.Scan(lo, hi), expected)
+}
+
+func (c *checker[K, V]) compareSeq(name string, actual iter.Seq2[K, V], expected iter.Seq[entry[K, V]]) error {
+	next, stop := iter.Pull(expected)
+	defer stop()
+
+	var i int
+	for k, v := range actual {
+		want, ok := next()
+		if !ok {
+			return fmt.Errorf("%s: yielded extra pair (%v, %v) at index %d", name, k, v, i)
+		}
+		if c.cfg.Cmp(k, want.key) != 0 || !c.cfg.Equal(v, want.value) {
+			return fmt.Errorf("%s: yielded (%v, %v) at index %d, want (%v, %v)", name, k, v, i, want.key, want.value)
+		}
+		i++
+	}
+	if want, ok := next(); ok {
+		return fmt.Errorf("%s: ended at index %d, want (%v, %v) next", name, i, want.key, want.value)
+	}
+	return nil
+}
+
+func backward[S ~[]E, E any](s S) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package btreetest
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sliceMap is a straightforward OrderedMap used to test the checker itself.
+// If dropEvery is non-zero, every dropEvery-th insertion of a new key is lost.
+type sliceMap struct {
+	keys      []int
+	values    []int
+	inserted  int
+	dropEvery int
+}
+
+func (m *sliceMap) Insert(key, value int) {
+	i, found := slices.BinarySearch(m.keys, key)
+	if found {
+		m.values[i] = value
+		return
+	}
+	m.inserted++
+	if m.dropEvery > 0 && m.inserted%m.dropEvery == 0 {
+		return
+	}
+	m.keys = slices.Insert(m.keys, i, key)
+	m.values = slices.Insert(m.values, i, value)
+}
+
+func (m *sliceMap) Get(key int) (int, bool) {
+	i, found := slices.BinarySearch(m.keys, key)
+	if !found {
+		return 0, false
+	}
+	return m.values[i], true
+}
+
+func (m *sliceMap) Remove(key int) bool {
+	i, found := slices.BinarySearch(m.keys, key)
+	if !found {
+		return false
+	}
+	m.keys = slices.Delete(m.keys, i, i+1)
+	m.values = slices.Delete(m.values, i, i+1)
+	return true
+}
+
+func (m *sliceMap) Len() int { return len(m.keys) }
+
+func (m *sliceMap) Min() (int, int, bool) {
+	if len(m.keys) == 0 {
+		return 0, 0, false
+	}
+	return m.keys[0], m.values[0], true
+}
+
+func (m *sliceMap) Max() (int, int, bool) {
+	if len(m.keys) == 0 {
+		return 0, 0, false
+	}
+	return m.keys[len(m.keys)-1], m.values[len(m.values)-1], true
+}
+
+func (m *sliceMap) All() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i := range m.keys {
+			if !yield(m.keys[i], m.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (m *sliceMap) Backward() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i := len(m.keys) - 1; i >= 0; i-- {
+			if !yield(m.keys[i], m.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (m *sliceMap) Scan(lo, hi int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		if lo <= hi {
+			for k, v := range m.All() {
+				if k >= lo && k <= hi && !yield(k, v) {
+					return
+				}
+			}
+		} else {
+			for k, v := range m.Backward() {
+				if k <= lo && k >= hi && !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+type invalidMap struct {
+	sliceMap
+}
+
+func (m *invalidMap) Validate() error {
+	if len(m.keys) > 10 {
+		return errTooLarge
+	}
+	return nil
+}
+
+var errTooLarge = errors.New("too large")
+
+func TestTestOrderedMap(t *testing.T) {
+	cfg := IntConfig(100, 1)
+	if err := TestOrderedMap(&sliceMap{}, cfg); err != nil {
+		t.Fatalf("correct map: unexpected error: %v", err)
+	}
+
+	err := TestOrderedMap(&sliceMap{dropEvery: 50}, cfg)
+	if err == nil || !strings.Contains(err.Error(), "Len()") {
+		t.Fatalf("map dropping insertion: error should report Len mismatch but is %v", err)
+	}
+
+	err = TestOrderedMap(&invalidMap{}, cfg)
+	if err == nil || !strings.Contains(err.Error(), "Validate(): too large") {
+		t.Fatalf("invalid map: error should report Validate failure but is %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package btree
+
+import (
+	"testing"
+
+	"github.com/ngicks/go-common/btree/btreetest"
+)
+
+// persistentAdapter adapts PersistentLLBTree to btreetest.OrderedMap
+// by replacing the tree on every update.
+type persistentAdapter struct {
+	*PersistentLLBTree[int, int]
+}
+
+func (a *persistentAdapter) Insert(key, value int) {
+	a.PersistentLLBTree = a.PersistentLLBTree.Insert(key, value)
+}
+
+func (a *persistentAdapter) Remove(key int) bool {
+	var removed bool
+	a.PersistentLLBTree, removed = a.PersistentLLBTree.Remove(key)
+	return removed
+}
+
+func TestOrderedMap_btreetest(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		new  func() btreetest.OrderedMap[int, int]
+	}{
+		{"LLBTree", func() btreetest.OrderedMap[int, int] { return NewLLBTreeOrdered[int, int]() }},
+		{"LLBTree-slab-reuse", func() btreetest.OrderedMap[int, int] {
+			return NewLLBTreeOrdered[int, int](SetSlabSize(16), SetNodeReuse(true))
+		}},
+		{"PersistentLLBTree", func() btreetest.OrderedMap[int, int] {
+			return &persistentAdapter{NewPersistentLLBTreeOrdered[int, int]()}
+		}},
+		{"SyncLLBTree", func() btreetest.OrderedMap[int, int] { return NewSyncLLBTreeOrdered[int, int]() }},
+		{"BTree-2", func() btreetest.OrderedMap[int, int] { return NewBTreeOrdered[int, int](2) }},
+		{"BTree-5", func() btreetest.OrderedMap[int, int] { return NewBTreeOrdered[int, int](5) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, keySpace := range []int{16, 1000} {
+				for seed := range uint64(3) {
+					if err := btreetest.TestOrderedMap(tc.new(), btreetest.IntConfig(keySpace, seed)); err != nil {
+						t.Errorf("keySpace = %d, seed = %d: %v", keySpace, seed, err)
+					}
+				}
+			}
+		})
+	}
+}
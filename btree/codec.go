@@ -0,0 +1,117 @@
+package btree
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// Codec encodes and decodes values of type T for binary serialization of trees.
+// See [LLBTree.SetCodec].
+type Codec[T any] interface {
+	// AppendEncode appends the encoded form of v to dst and returns the extended buffer.
+	AppendEncode(dst []byte, v T) ([]byte, error)
+	// Decode decodes src, which holds exactly one encoded value.
+	// src may be reused after Decode returns, so implementations must not retain it.
+	Decode(src []byte) (T, error)
+}
+
+var (
+	_ Codec[string]   = StringCodec[string]{}
+	_ Codec[[]byte]   = BytesCodec{}
+	_ Codec[int]      = IntCodec[int]{}
+	_ Codec[uint]     = UintCodec[uint]{}
+	_ Codec[any]      = JSONCodec[any]{}
+	_ Codec[struct{}] = EmptyCodec{}
+)
+
+// StringCodec encodes strings as their raw bytes.
+type StringCodec[T ~string] struct{}
+
+func (StringCodec[T]) AppendEncode(dst []byte, v T) ([]byte, error) {
+	return append(dst, v...), nil
+}
+
+func (StringCodec[T]) Decode(src []byte) (T, error) {
+	return T(src), nil
+}
+
+// BytesCodec encodes byte slices as is.
+type BytesCodec struct{}
+
+func (BytesCodec) AppendEncode(dst []byte, v []byte) ([]byte, error) {
+	return append(dst, v...), nil
+}
+
+func (BytesCodec) Decode(src []byte) ([]byte, error) {
+	return append([]byte{}, src...), nil
+}
+
+// IntCodec encodes signed integers in the varint format of [encoding/binary].
+type IntCodec[T ~int | ~int8 | ~int16 | ~int32 | ~int64] struct{}
+
+func (IntCodec[T]) AppendEncode(dst []byte, v T) ([]byte, error) {
+	return binary.AppendVarint(dst, int64(v)), nil
+}
+
+func (IntCodec[T]) Decode(src []byte) (T, error) {
+	x, n := binary.Varint(src)
+	if n <= 0 || n != len(src) {
+		return 0, fmt.Errorf("invalid varint %x", src)
+	}
+	if v := T(x); int64(v) == x {
+		return v, nil
+	}
+	return 0, fmt.Errorf("varint %d overflows %T", x, T(0))
+}
+
+// UintCodec encodes unsigned integers in the uvarint format of [encoding/binary].
+type UintCodec[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr] struct{}
+
+func (UintCodec[T]) AppendEncode(dst []byte, v T) ([]byte, error) {
+	return binary.AppendUvarint(dst, uint64(v)), nil
+}
+
+func (UintCodec[T]) Decode(src []byte) (T, error) {
+	x, n := binary.Uvarint(src)
+	if n <= 0 || n != len(src) {
+		return 0, fmt.Errorf("invalid uvarint %x", src)
+	}
+	if v := T(x); uint64(v) == x {
+		return v, nil
+	}
+	return 0, fmt.Errorf("uvarint %d overflows %T", x, T(0))
+}
+
+// JSONCodec encodes values by [encoding/json].
+// It is the default codec of trees.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) AppendEncode(dst []byte, v T) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, b...), nil
+}
+
+func (JSONCodec[T]) Decode(src []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(src, &v)
+	return v, err
+}
+
+// EmptyCodec encodes struct{} as zero bytes.
+// It is useful to serialize trees used as sets, e.g. the tree of [Set].
+type EmptyCodec struct{}
+
+func (EmptyCodec) AppendEncode(dst []byte, v struct{}) ([]byte, error) {
+	return dst, nil
+}
+
+func (EmptyCodec) Decode(src []byte) (struct{}, error) {
+	if len(src) != 0 {
+		return struct{}{}, fmt.Errorf("non empty input for empty value: %x", src)
+	}
+	return struct{}{}, nil
+}
@@ -1,3 +1,5 @@
 module github.com/ngicks/go-common/btree
 
 go 1.23.0
+
+require github.com/jonboulle/clockwork v0.4.0
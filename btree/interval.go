@@ -0,0 +1,188 @@
+package btree
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+)
+
+// Interval is a closed interval [Lo, Hi].
+type Interval[K any] struct {
+	Lo, Hi K
+}
+
+// IntervalTree maps closed intervals to values and answers overlap queries.
+//
+// IntervalTree is an [LLBTree] keyed by intervals, ordered by Lo and then by Hi,
+// whose nodes are augmented with the greatest Hi in their subtree.
+// Queries use it to skip subtrees which cannot contain any overlapping interval,
+// thus Overlapping takes O(k log n) time where k is the number of yielded intervals.
+//
+// Like LLBTree, IntervalTree is not safe for concurrent use.
+type IntervalTree[K, V any] struct {
+	cmp  func(l, r K) int
+	tree *LLBTree[Interval[K], intervalValue[K, V]]
+}
+
+type intervalValue[K, V any] struct {
+	value V
+	// maxHi is the greatest Hi in the subtree rooted at the node holding this value.
+	maxHi K
+}
+
+func NewIntervalTree[K, V any](cmp func(l, r K) int) *IntervalTree[K, V] {
+	t := &IntervalTree[K, V]{
+		cmp: cmp,
+		tree: NewLLBTree[Interval[K], intervalValue[K, V]](func(l, r Interval[K]) int {
+			if c := cmp(l.Lo, r.Lo); c != 0 {
+				return c
+			}
+			return cmp(l.Hi, r.Hi)
+		}),
+	}
+	t.tree.augment = t.augment
+	return t
+}
+
+func NewIntervalTreeOrdered[K cmp.Ordered, V any]() *IntervalTree[K, V] {
+	return NewIntervalTree[K, V](cmp.Compare[K])
+}
+
+func (t *IntervalTree[K, V]) augment(n *llbtreeNode[Interval[K], intervalValue[K, V]]) {
+	n.value.maxHi = t.maxHi(n)
+}
+
+// maxHi computes the greatest Hi in the subtree rooted at n from n and its children.
+func (t *IntervalTree[K, V]) maxHi(n *llbtreeNode[Interval[K], intervalValue[K, V]]) K {
+	maxHi := n.key.Hi
+	for _, c := range [...]*llbtreeNode[Interval[K], intervalValue[K, V]]{n.left, n.right} {
+		if c != nil && t.cmp(c.value.maxHi, maxHi) > 0 {
+			maxHi = c.value.maxHi
+		}
+	}
+	return maxHi
+}
+
+// Insert maps interval to value, replacing the value previously mapped to the same interval.
+// Insert panics if interval.Lo is greater than interval.Hi.
+func (t *IntervalTree[K, V]) Insert(interval Interval[K], value V) {
+	if t.cmp(interval.Lo, interval.Hi) > 0 {
+		panic(fmt.Sprintf("btree: invalid interval: Lo %v is greater than Hi %v", interval.Lo, interval.Hi))
+	}
+	t.tree.Insert(interval, intervalValue[K, V]{value: value, maxHi: interval.Hi})
+}
+
+// Get returns the value mapped to the interval which is exactly equal to interval.
+func (t *IntervalTree[K, V]) Get(interval Interval[K]) (value V, ok bool) {
+	v, ok := t.tree.Get(interval)
+	return v.value, ok
+}
+
+// Remove removes the interval which is exactly equal to interval.
+func (t *IntervalTree[K, V]) Remove(interval Interval[K]) (removed bool) {
+	return t.tree.Remove(interval)
+}
+
+// Len returns the number of intervals in t.
+func (t *IntervalTree[K, V]) Len() int {
+	return t.tree.Len()
+}
+
+// All returns an iterator over all intervals in t, ordered by Lo and then by Hi.
+func (t *IntervalTree[K, V]) All() iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		for k, v := range t.tree.All() {
+			if !yield(k, v.value) {
+				return
+			}
+		}
+	}
+}
+
+// Overlapping returns an iterator over intervals which overlap the closed interval [lo, hi],
+// ordered by Lo and then by Hi.
+// Intervals sharing only an endpoint with [lo, hi] are considered overlapping.
+//
+// Like [LLBTree.All], t may be mutated during iteration;
+// the iterator resumes from the least interval greater than the last yielded one.
+func (t *IntervalTree[K, V]) Overlapping(lo, hi K) iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		if t.cmp(lo, hi) > 0 {
+			return
+		}
+		var after *Interval[K]
+		for {
+			n := t.firstOverlapping(t.tree.root, after, lo, hi)
+			if n == nil {
+				return
+			}
+			key := n.key
+			if !yield(key, n.value.value) {
+				return
+			}
+			after = &key
+		}
+	}
+}
+
+// Stabbing returns an iterator over intervals which contain point.
+// It is same as Overlapping(point, point).
+func (t *IntervalTree[K, V]) Stabbing(point K) iter.Seq2[Interval[K], V] {
+	return t.Overlapping(point, point)
+}
+
+// firstOverlapping returns the least node in the subtree rooted at n
+// whose key is greater than after, if after is non-nil, and overlaps [lo, hi].
+func (t *IntervalTree[K, V]) firstOverlapping(
+	n *llbtreeNode[Interval[K], intervalValue[K, V]],
+	after *Interval[K],
+	lo, hi K,
+) *llbtreeNode[Interval[K], intervalValue[K, V]] {
+	for n != nil {
+		if t.cmp(n.value.maxHi, lo) < 0 {
+			// every interval in the subtree ends before lo.
+			return nil
+		}
+		if after != nil && t.tree.cmp(n.key, *after) <= 0 {
+			n = n.right
+			continue
+		}
+		if found := t.firstOverlapping(n.left, after, lo, hi); found != nil {
+			return found
+		}
+		if t.cmp(n.key.Lo, hi) > 0 {
+			// n and every interval in the right subtree start after hi.
+			return nil
+		}
+		if t.cmp(n.key.Hi, lo) >= 0 {
+			return n
+		}
+		n = n.right
+	}
+	return nil
+}
+
+// Validate is like [LLBTree.Validate]
+// but it additionally checks that the max endpoint cached in every node is up to date.
+func (t *IntervalTree[K, V]) Validate() error {
+	if err := t.tree.Validate(); err != nil {
+		return err
+	}
+	var walk func(n *llbtreeNode[Interval[K], intervalValue[K, V]]) error
+	walk = func(n *llbtreeNode[Interval[K], intervalValue[K, V]]) error {
+		if n == nil {
+			return nil
+		}
+		if err := walk(n.left); err != nil {
+			return err
+		}
+		if err := walk(n.right); err != nil {
+			return err
+		}
+		if cached, actual := n.value.maxHi, t.maxHi(n); t.cmp(cached, actual) != 0 {
+			return fmt.Errorf("stale max endpoint at %s: cached = %v, actual = %v", describeNode(n), cached, actual)
+		}
+		return nil
+	}
+	return walk(t.tree.root)
+}
@@ -0,0 +1,152 @@
+package btree
+
+import (
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestIntervalTree(t *testing.T) {
+	tree := NewIntervalTreeOrdered[int, string]()
+
+	tree.Insert(Interval[int]{1, 3}, "a")
+	tree.Insert(Interval[int]{2, 8}, "b")
+	tree.Insert(Interval[int]{5, 6}, "c")
+	tree.Insert(Interval[int]{9, 9}, "d")
+	tree.Insert(Interval[int]{2, 8}, "B")
+	assertValid(t, tree)
+
+	if l := tree.Len(); l != 4 {
+		t.Fatalf("Len() = %d, want 4", l)
+	}
+	if v, ok := tree.Get(Interval[int]{2, 8}); !ok || v != "B" {
+		t.Fatalf("Get({2, 8}) = (%q, %t), want (\"B\", true)", v, ok)
+	}
+	if _, ok := tree.Get(Interval[int]{2, 7}); ok {
+		t.Fatal("Get({2, 7}) should not be found")
+	}
+
+	for _, tc := range []struct {
+		lo, hi int
+		want   []string
+	}{
+		{0, 0, nil},
+		{0, 1, []string{"a"}},
+		{3, 5, []string{"a", "B", "c"}},
+		{7, 9, []string{"B", "d"}},
+		{10, 20, nil},
+		{0, 20, []string{"a", "B", "c", "d"}},
+		{5, 3, nil},
+	} {
+		got := slices.Collect(values(tree.Overlapping(tc.lo, tc.hi)))
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Overlapping(%d, %d) = %v, want %v", tc.lo, tc.hi, got, tc.want)
+		}
+	}
+	if got := slices.Collect(values(tree.Stabbing(6))); !slices.Equal(got, []string{"B", "c"}) {
+		t.Errorf("Stabbing(6) = %v, want [B c]", got)
+	}
+
+	if !tree.Remove(Interval[int]{2, 8}) {
+		t.Fatal("Remove({2, 8}) = false")
+	}
+	if tree.Remove(Interval[int]{2, 8}) {
+		t.Fatal("Remove({2, 8}) = true for removed interval")
+	}
+	assertValid(t, tree)
+	if got := slices.Collect(values(tree.Stabbing(6))); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("Stabbing(6) = %v, want [c]", got)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Insert with Lo > Hi should panic")
+			}
+		}()
+		tree.Insert(Interval[int]{2, 1}, "")
+	}()
+}
+
+func TestIntervalTree_remove_while_iteration(t *testing.T) {
+	tree := NewIntervalTreeOrdered[int, int]()
+	for i := range 100 {
+		tree.Insert(Interval[int]{i, i + 10}, i)
+	}
+	var seen []int
+	for k, v := range tree.Overlapping(30, 40) {
+		seen = append(seen, v)
+		tree.Remove(k)
+	}
+	assertValid(t, tree)
+	var want []int
+	for i := 20; i <= 40; i++ {
+		want = append(want, i)
+	}
+	if !slices.Equal(seen, want) {
+		t.Fatalf("yielded %v, want %v", seen, want)
+	}
+	if got := slices.Collect(values(tree.Overlapping(30, 40))); len(got) != 0 {
+		t.Fatalf("Overlapping(30, 40) after removal = %v, want empty", got)
+	}
+	if l := tree.Len(); l != 79 {
+		t.Fatalf("Len() = %d, want 79", l)
+	}
+}
+
+func TestIntervalTree_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree := NewIntervalTreeOrdered[int, int]()
+	model := map[Interval[int]]int{}
+
+	randInterval := func() Interval[int] {
+		lo := r.IntN(1000)
+		return Interval[int]{lo, lo + r.IntN(50)}
+	}
+
+	for i := range 5000 {
+		iv := randInterval()
+		if r.IntN(3) == 0 {
+			_, want := model[iv]
+			if got := tree.Remove(iv); got != want {
+				t.Fatalf("Remove(%v) = %t, want %t", iv, got, want)
+			}
+			delete(model, iv)
+		} else {
+			tree.Insert(iv, i)
+			model[iv] = i
+		}
+		if i%100 != 0 {
+			continue
+		}
+		assertValid(t, tree)
+		for range 10 {
+			q := randInterval()
+			var want []Interval[int]
+			for _, k := range slices.SortedFunc(maps.Keys(model), tree.tree.cmp) {
+				if k.Lo <= q.Hi && q.Lo <= k.Hi {
+					want = append(want, k)
+				}
+			}
+			got := slices.Collect(keys(tree.Overlapping(q.Lo, q.Hi)))
+			if !slices.Equal(got, want) {
+				t.Fatalf("Overlapping(%d, %d) = %v, want %v", q.Lo, q.Hi, got, want)
+			}
+		}
+	}
+	if l := tree.Len(); l != len(model) {
+		t.Fatalf("Len() = %d, want %d", l, len(model))
+	}
+}
+
+func values[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
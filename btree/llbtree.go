@@ -5,25 +5,47 @@ import (
 	"iter"
 )
 
+// LLBTree is a left-leaning red-black tree.
+//
+// LLBTree is not safe for concurrent use.
+// Use [SyncLLBTree] to share a tree among goroutines.
 type LLBTree[K, V any] struct {
 	cmp      func(l, r K) int
 	root     *llbtreeNode[K, V]
 	min, max *llbtreeNode[K, V]
-}
-
-func NewLLBTree[K, V any](cmp func(l, r K) int) *LLBTree[K, V] {
-	return &LLBTree[K, V]{
+	// shared is true if nodes are referenced by a snapshot taken by Snapshot.
+	shared bool
+	// gen is incremented each time nodes are replaced by unshare
+	// so that iterators can notice they hold stale nodes.
+	gen uint64
+	// augment, if non-nil, recomputes data derived from the subtree rooted at a node
+	// (e.g. the max endpoint for IntervalTree).
+	// It is called right after the node's size is recomputed, children first.
+	augment func(n *llbtreeNode[K, V])
+	// keyCodec and valueCodec are used for binary encoding. See SetCodec.
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+	alloc      llbtreeAlloc[K, V]
+}
+
+// NewLLBTree returns an empty tree ordered by cmp.
+// options configure how the tree allocates nodes. See [SetSlabSize] and [SetNodeReuse].
+func NewLLBTree[K, V any](cmp func(l, r K) int, options ...LLBTreeOption) *LLBTree[K, V] {
+	t := &LLBTree[K, V]{
 		cmp: cmp,
 	}
+	for _, opt := range options {
+		opt(&t.alloc.param)
+	}
+	return t
 }
 
-func NewLLBTreeOrdered[K cmp.Ordered, V any]() *LLBTree[K, V] {
-	return &LLBTree[K, V]{
-		cmp: cmp.Compare[K],
-	}
+func NewLLBTreeOrdered[K cmp.Ordered, V any](options ...LLBTreeOption) *LLBTree[K, V] {
+	return NewLLBTree[K, V](cmp.Compare[K], options...)
 }
 
 func (t *LLBTree[K, V]) Insert(key K, value V) {
+	t.unshare()
 	t.insert(key, value)
 	if t.min == nil || t.cmp(key, t.min.key) == -1 {
 		t.min = nil
@@ -50,6 +72,11 @@ func (t *LLBTree[K, V]) Get(key K) (value V, ok bool) {
 }
 
 func (t *LLBTree[K, V]) Remove(key K) (removed bool) {
+	if t.shared && t.get(key) == nil {
+		// avoid copying nodes only to find nothing to remove.
+		return false
+	}
+	t.unshare()
 	removed = t.delete(key)
 	if removed {
 		if t.min != nil && t.cmp(key, t.min.key) == 0 {
@@ -94,6 +121,86 @@ func (t *LLBTree[K, V]) Max() (key K, value V, ok bool) {
 	return t.max.key, t.max.value, true
 }
 
+// Floor returns the element with the greatest key less than or equal to key.
+func (t *LLBTree[K, V]) Floor(key K) (k K, v V, ok bool) {
+	return t.floor(key).entry()
+}
+
+// Ceiling returns the element with the least key greater than or equal to key.
+func (t *LLBTree[K, V]) Ceiling(key K) (k K, v V, ok bool) {
+	return t.ceiling(key).entry()
+}
+
+// Lower returns the element with the greatest key strictly less than key.
+func (t *LLBTree[K, V]) Lower(key K) (k K, v V, ok bool) {
+	return t.root.prevBefore(t.cmp, &t.root, key).entry()
+}
+
+// Higher returns the element with the least key strictly greater than key.
+func (t *LLBTree[K, V]) Higher(key K) (k K, v V, ok bool) {
+	return t.root.nextAfter(t.cmp, &t.root, key).entry()
+}
+
+// Len returns the number of elements in t.
+func (t *LLBTree[K, V]) Len() int {
+	return t.root.len()
+}
+
+// Rank returns the number of keys in t strictly less than key.
+// key does not need to be in t.
+func (t *LLBTree[K, V]) Rank(key K) int {
+	var rank int
+	n := t.root
+	for n != nil {
+		if t.cmp(key, n.key) <= 0 {
+			n = n.left
+		} else {
+			rank += n.left.len() + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the i-th smallest key-value pair of t, counting from 0.
+// ok is false if i is out of range.
+func (t *LLBTree[K, V]) Select(i int) (key K, value V, ok bool) {
+	n := t.selectNode(i)
+	if n == nil {
+		return
+	}
+	return n.key, n.value, true
+}
+
+func (t *LLBTree[K, V]) selectNode(i int) *llbtreeNode[K, V] {
+	if i < 0 || i >= t.root.len() {
+		return nil
+	}
+	n := t.root
+	for n != nil {
+		l := n.left.len()
+		switch {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// CountRange returns the number of keys k in t where lo <= k < hi.
+// It returns 0 if lo >= hi.
+func (t *LLBTree[K, V]) CountRange(lo, hi K) int {
+	if t.cmp(lo, hi) >= 0 {
+		return 0
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
 func (t *LLBTree[K, V]) All() iter.Seq2[K, V] {
 	return t.nextInRange(
 		func() *llbtreeNode[K, V] {
@@ -119,15 +226,7 @@ func (t *LLBTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
 	case -1, 0: // lo <= hi
 		return t.nextInRange(
 			func() *llbtreeNode[K, V] {
-				loc, parent := t.root.findLocation(&t.root, lo)
-				if loc == nil || *loc == nil {
-					if loc == &parent.left {
-						return parent
-					} else {
-						return parent.ascendFromRight()
-					}
-				}
-				return *loc
+				return t.ceiling(lo)
 			},
 			true,
 			hi,
@@ -135,15 +234,7 @@ func (t *LLBTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
 	default: // lo > hi
 		return t.prevInRange(
 			func() *llbtreeNode[K, V] {
-				loc, parent := t.root.findLocation(&t.root, lo)
-				if loc == nil || *loc == nil {
-					if loc == &parent.right {
-						return parent
-					} else {
-						return parent.ascendFromLeft()
-					}
-				}
-				return *loc
+				return t.floor(lo)
 			},
 			true,
 			hi,
@@ -151,6 +242,44 @@ func (t *LLBTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
 	}
 }
 
+// Snapshot returns an immutable view of t's current content.
+//
+// Snapshot itself does not copy anything: t and the returned tree share nodes
+// until t is mutated next time. That first mutation copies the whole tree, taking O(n) time,
+// and later ones run at the usual cost until the next Snapshot.
+// If snapshots are taken between most updates, use [PersistentLLBTree] directly,
+// whose updates copy only O(log n) nodes.
+// The snapshot, and iterators obtained from it, are not affected by later mutations on t.
+func (t *LLBTree[K, V]) Snapshot() *PersistentLLBTree[K, V] {
+	t.shared = true
+	return &PersistentLLBTree[K, V]{
+		cmp:  t.cmp,
+		root: t.root,
+	}
+}
+
+// unshare copies all nodes in O(n) time if they are shared with snapshots.
+// Every method mutating nodes must call this beforehand.
+func (t *LLBTree[K, V]) unshare() {
+	if !t.shared {
+		return
+	}
+	t.shared = false
+	t.gen++
+	t.setRoot(t.root.clone())
+	t.min, t.max = nil, nil
+	t.Min()
+	t.Max()
+}
+
+// update recomputes the size and augmented data of n from its children.
+func (t *LLBTree[K, V]) update(n *llbtreeNode[K, V]) {
+	n.updateSize()
+	if t.augment != nil {
+		t.augment(n)
+	}
+}
+
 func (t *LLBTree[K, V]) setRoot(x *llbtreeNode[K, V]) {
 	t.root = x
 	if x != nil {
@@ -200,6 +329,9 @@ func (t *LLBTree[K, V]) rotateRight(y *llbtreeNode[K, V]) (newRoot *llbtreeNode[
 	x.red = y.red
 	y.red = true
 
+	t.update(y)
+	t.update(x)
+
 	return x
 }
 
@@ -228,31 +360,24 @@ func (t *LLBTree[K, V]) rotateLeft(x *llbtreeNode[K, V]) (newRoot *llbtreeNode[K
 	y.red = x.red
 	x.red = true
 
+	t.update(x)
+	t.update(y)
+
 	return y
 }
 
 // insertBtree does standard btree insertion.
 func (t *LLBTree[K, V]) insertBtree(k K, v V) *llbtreeNode[K, V] {
 	if t.root == nil {
-		t.root = &llbtreeNode[K, V]{
-			cmp:   t.cmp,
-			red:   true,
-			key:   k,
-			value: v,
-		}
+		t.root = t.newNode(k, v)
 		return t.root
 	}
-	loc, parent := t.root.findLocation(&t.root, k)
+	loc, parent := t.root.findLocation(t.cmp, &t.root, k)
 	if *loc != nil {
 		(*loc).value = v
 	} else {
-		*loc = &llbtreeNode[K, V]{
-			cmp:    t.cmp,
-			parent: parent,
-			red:    true,
-			key:    k,
-			value:  v,
-		}
+		*loc = t.newNode(k, v)
+		(*loc).parent = parent
 	}
 	return *loc
 }
@@ -308,6 +433,9 @@ func (t *LLBTree[K, V]) fixup(tgt *llbtreeNode[K, V]) {
 		if n.left.isRed() && n.right.isRed() {
 			n.flipColor()
 		}
+		// Every structural change happens on the path from tgt to the root,
+		// so recomputing sizes (and augmented data) along the way keeps them all consistent.
+		t.update(n)
 		n = n.parent
 	}
 	if t.root != nil {
@@ -403,16 +531,50 @@ func (t *LLBTree[K, V]) delete(key K) (deleted bool) {
 	x.parent = nil
 	x.left = nil
 	x.right = nil
+	x.size = 0
 	x.deleted = true
+	t.freeNode(x)
 
 	return true
 }
 
+// ceiling returns the node with the least key greater than or equal to k.
+func (t *LLBTree[K, V]) ceiling(k K) *llbtreeNode[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	loc, parent := t.root.findLocation(t.cmp, &t.root, k)
+	if *loc == nil {
+		if loc == &parent.left {
+			return parent
+		} else {
+			return parent.ascendFromRight()
+		}
+	}
+	return *loc
+}
+
+// floor returns the node with the greatest key less than or equal to k.
+func (t *LLBTree[K, V]) floor(k K) *llbtreeNode[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	loc, parent := t.root.findLocation(t.cmp, &t.root, k)
+	if *loc == nil {
+		if loc == &parent.right {
+			return parent
+		} else {
+			return parent.ascendFromLeft()
+		}
+	}
+	return *loc
+}
+
 func (t *LLBTree[K, V]) get(k K) *llbtreeNode[K, V] {
 	if t.root == nil {
 		return nil
 	}
-	loc, _ := t.root.findLocation(&t.root, k)
+	loc, _ := t.root.findLocation(t.cmp, &t.root, k)
 	if loc == nil {
 		return nil
 	}
@@ -433,13 +595,16 @@ func (t *LLBTree[K, V]) nextInRange(start func() *llbtreeNode[K, V], limit bool,
 			return
 		}
 
-		if !yield(current.key, current.value) {
+		// key is copied since current may be removed and then reused for another key.
+		key, gen := current.key, t.gen
+		if !yield(key, current.value) {
 			return
 		}
 		var next *llbtreeNode[K, V]
 		for {
-			if current.deleted {
-				next = t.root.nextAfter(&t.root, current.key)
+			if current.deleted || gen != t.gen {
+				next = t.root.nextAfter(t.cmp, &t.root, key)
+				gen = t.gen
 			} else {
 				next = current.next(&t.root)
 			}
@@ -452,7 +617,8 @@ func (t *LLBTree[K, V]) nextInRange(start func() *llbtreeNode[K, V], limit bool,
 				return
 			}
 
-			if !yield(next.key, next.value) {
+			key = next.key
+			if !yield(key, next.value) {
 				return
 			}
 
@@ -475,13 +641,16 @@ func (t *LLBTree[K, V]) prevInRange(start func() *llbtreeNode[K, V], limit bool,
 			return
 		}
 
-		if !yield(current.key, current.value) {
+		// key is copied since current may be removed and then reused for another key.
+		key, gen := current.key, t.gen
+		if !yield(key, current.value) {
 			return
 		}
 		var next *llbtreeNode[K, V]
 		for {
-			if current.deleted {
-				next = t.root.prevBefore(&t.root, current.key)
+			if current.deleted || gen != t.gen {
+				next = t.root.prevBefore(t.cmp, &t.root, key)
+				gen = t.gen
 			} else {
 				next = current.prev(&t.root)
 			}
@@ -494,7 +663,8 @@ func (t *LLBTree[K, V]) prevInRange(start func() *llbtreeNode[K, V], limit bool,
 				return
 			}
 
-			if !yield(next.key, next.value) {
+			key = next.key
+			if !yield(key, next.value) {
 				return
 			}
 
@@ -503,15 +673,35 @@ func (t *LLBTree[K, V]) prevInRange(start func() *llbtreeNode[K, V], limit bool,
 	}
 }
 
+// llbtreeNode is kept small since a tree may hold millions of them:
+// the comparator is passed by the tree rather than stored in every node.
 type llbtreeNode[K, V any] struct {
-	cmp                 func(l, r K) int
 	parent, left, right *llbtreeNode[K, V]
 	deleted             bool
 	red                 bool
+	size                int // number of nodes in the subtree rooted at this node.
 	key                 K
 	value               V
 }
 
+// clone deeply copies the subtree rooted at n.
+func (n *llbtreeNode[K, V]) clone() *llbtreeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.setLeft(n.left.clone())
+	c.setRight(n.right.clone())
+	return &c
+}
+
+func (n *llbtreeNode[K, V]) entry() (key K, value V, ok bool) {
+	if n == nil {
+		return
+	}
+	return n.key, n.value, true
+}
+
 func (n *llbtreeNode[K, V]) loc(root **llbtreeNode[K, V]) **llbtreeNode[K, V] {
 	if n.parent == nil {
 		return root
@@ -523,13 +713,13 @@ func (n *llbtreeNode[K, V]) loc(root **llbtreeNode[K, V]) **llbtreeNode[K, V] {
 	}
 }
 
-func (n *llbtreeNode[K, V]) findLocation(root **llbtreeNode[K, V], k K) (loc **llbtreeNode[K, V], parent *llbtreeNode[K, V]) {
+func (n *llbtreeNode[K, V]) findLocation(cmp func(l, r K) int, root **llbtreeNode[K, V], k K) (loc **llbtreeNode[K, V], parent *llbtreeNode[K, V]) {
 	if n == nil {
 		return nil, nil
 	}
 	loc = n.loc(root)
 	for *loc != nil {
-		switch n.cmp(k, (*loc).key) {
+		switch cmp(k, (*loc).key) {
 		case 0:
 			return
 		case 1: // k > node.key
@@ -561,11 +751,11 @@ func (n *llbtreeNode[K, V]) next(root **llbtreeNode[K, V]) *llbtreeNode[K, V] {
 	return n.ascendFromRight()
 }
 
-func (n *llbtreeNode[K, V]) nextAfter(root **llbtreeNode[K, V], k K) *llbtreeNode[K, V] {
+func (n *llbtreeNode[K, V]) nextAfter(cmp func(l, r K) int, root **llbtreeNode[K, V], k K) *llbtreeNode[K, V] {
 	if n == nil {
 		return nil
 	}
-	loc, parent := n.findLocation(root, k)
+	loc, parent := n.findLocation(cmp, root, k)
 	if *loc == nil {
 		if loc == &parent.left {
 			return parent
@@ -596,11 +786,11 @@ func (n *llbtreeNode[K, V]) prev(root **llbtreeNode[K, V]) *llbtreeNode[K, V] {
 	return n.ascendFromLeft()
 }
 
-func (n *llbtreeNode[K, V]) prevBefore(root **llbtreeNode[K, V], k K) *llbtreeNode[K, V] {
+func (n *llbtreeNode[K, V]) prevBefore(cmp func(l, r K) int, root **llbtreeNode[K, V], k K) *llbtreeNode[K, V] {
 	if n == nil {
 		return nil
 	}
-	loc, parent := n.findLocation(root, k)
+	loc, parent := n.findLocation(cmp, root, k)
 	if *loc == nil {
 		if loc == &parent.right {
 			return parent
@@ -661,6 +851,17 @@ func (n *llbtreeNode[K, V]) rightmost(root **llbtreeNode[K, V]) **llbtreeNode[K,
 	return node
 }
 
+func (n *llbtreeNode[K, V]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *llbtreeNode[K, V]) updateSize() {
+	n.size = 1 + n.left.len() + n.right.len()
+}
+
 func (n *llbtreeNode[K, V]) isLeaf() bool {
 	if n == nil {
 		return true
@@ -0,0 +1,79 @@
+package btree
+
+// LLBTreeOption configures node allocation of [LLBTree]. See [NewLLBTree].
+type LLBTreeOption func(*llbtreeParam)
+
+type llbtreeParam struct {
+	slabSize int
+	reuse    bool
+}
+
+// SetSlabSize makes the tree allocate nodes in slabs of size nodes rather than one by one,
+// reducing the number of allocations, and objects the GC has to track, roughly by the factor of size.
+//
+// A slab is kept alive as long as any of its nodes is,
+// so a tree which grows large and then shrinks may retain more memory than without slabs.
+// Combine it with [SetNodeReuse] for workloads repeatedly inserting and removing elements.
+// size less than or equal to 1 disables slab allocation.
+func SetSlabSize(size int) LLBTreeOption {
+	return func(p *llbtreeParam) {
+		p.slabSize = size
+	}
+}
+
+// SetNodeReuse makes the tree keep removed nodes in a free list
+// and reuse them for later insertions instead of allocating new ones.
+// The free list grows up to the largest number of elements the tree has ever held.
+//
+// Iterators and cursors keep working across node reuse,
+// but a [Cursor] whose element has been removed may report, from Value,
+// the value as of the last time the cursor observed it.
+func SetNodeReuse(reuse bool) LLBTreeOption {
+	return func(p *llbtreeParam) {
+		p.reuse = reuse
+	}
+}
+
+type llbtreeAlloc[K, V any] struct {
+	param llbtreeParam
+	// slab holds nodes not yet handed out.
+	slab []llbtreeNode[K, V]
+	// free is the head of the list of removed nodes, linked through right.
+	free *llbtreeNode[K, V]
+}
+
+// newNode returns a red node of size 1 holding k and v, not linked to any other node.
+func (t *LLBTree[K, V]) newNode(k K, v V) *llbtreeNode[K, V] {
+	a := &t.alloc
+	var n *llbtreeNode[K, V]
+	switch {
+	case a.free != nil:
+		n, a.free = a.free, a.free.right
+		// n may still be referenced by iterators or cursors as a deleted node.
+		// They notice the reuse by the change of gen and find their position again by the key they copied.
+		t.gen++
+		*n = llbtreeNode[K, V]{}
+	case a.param.slabSize > 1:
+		if len(a.slab) == 0 {
+			a.slab = make([]llbtreeNode[K, V], a.param.slabSize)
+		}
+		n, a.slab = &a.slab[0], a.slab[1:]
+	default:
+		n = new(llbtreeNode[K, V])
+	}
+	n.red = true
+	n.size = 1
+	n.key = k
+	n.value = v
+	return n
+}
+
+// freeNode puts n, which is just removed from t, to the free list if node reuse is enabled.
+// n keeps its key and value until reused, so that iterators and cursors referencing it can read them.
+func (t *LLBTree[K, V]) freeNode(n *llbtreeNode[K, V]) {
+	if !t.alloc.param.reuse {
+		return
+	}
+	n.right = t.alloc.free
+	t.alloc.free = n
+}
@@ -0,0 +1,84 @@
+package btree
+
+import (
+	"slices"
+	"testing"
+)
+
+var allocOptions = []struct {
+	name    string
+	options []LLBTreeOption
+}{
+	{"slab", []LLBTreeOption{SetSlabSize(4)}},
+	{"reuse", []LLBTreeOption{SetNodeReuse(true)}},
+	{"slab-reuse", []LLBTreeOption{SetSlabSize(4), SetNodeReuse(true)}},
+}
+
+func TestLLBTree_node_reuse_while_iteration(t *testing.T) {
+	for _, tc := range allocOptions {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewLLBTreeOrdered[int, int](tc.options...)
+			for i := range 10 {
+				tree.Insert(i, i)
+			}
+
+			// Removing the yielded element and inserting another lets the tree reuse the yielded node.
+			var yielded []int
+			for k := range tree.All() {
+				yielded = append(yielded, k)
+				tree.Remove(k)
+				if k < 10 {
+					tree.Insert(k+100, k)
+				}
+				assertValid(t, tree)
+			}
+			want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109}
+			if !slices.Equal(yielded, want) {
+				t.Fatalf("yielded = %v, want %v", yielded, want)
+			}
+
+			for i := range 10 {
+				tree.Insert(i, i)
+			}
+			yielded = yielded[:0]
+			for k := range tree.Backward() {
+				yielded = append(yielded, k)
+				tree.Remove(k)
+				tree.Insert(k+100, k)
+				if len(yielded) == 3 {
+					break
+				}
+			}
+			if !slices.Equal(yielded, []int{9, 8, 7}) {
+				t.Fatalf("yielded = %v, want [9 8 7]", yielded)
+			}
+		})
+	}
+}
+
+func TestLLBTree_node_reuse_cursor(t *testing.T) {
+	for _, tc := range allocOptions {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewLLBTreeOrdered[int, string](tc.options...)
+			for i := range 10 {
+				tree.Insert(i*2, "v")
+			}
+
+			c := tree.Seek(6)
+			tree.Remove(6)
+			tree.Insert(7, "new")
+			if c.Valid() {
+				t.Fatal("cursor should be invalid after its element is removed")
+			}
+			if k, v := c.Key(), c.Value(); k != 6 || v != "v" {
+				t.Fatalf("Key(), Value() = %d, %q, want 6, \"v\"", k, v)
+			}
+			if !c.Next() || c.Key() != 7 || c.Value() != "new" {
+				t.Fatalf("Next() moved to %d, want 7", c.Key())
+			}
+			if !c.Prev() || c.Key() != 4 {
+				t.Fatalf("Prev() moved to %d, want 4", c.Key())
+			}
+		})
+	}
+}
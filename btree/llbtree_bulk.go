@@ -0,0 +1,234 @@
+package btree
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+var (
+	ErrNotSorted   = errors.New("not sorted")
+	ErrOverlapping = errors.New("overlapping")
+)
+
+// BuildSorted replaces the content of t with key-value pairs from seq in O(n) time,
+// where n is the number of pairs.
+// Keys from seq must be in strictly ascending order.
+// Otherwise BuildSorted returns an error wrapping [ErrNotSorted] and leaves t unchanged.
+func (t *LLBTree[K, V]) BuildSorted(seq iter.Seq2[K, V]) error {
+	var items []btreeItem[K, V]
+	for k, v := range seq {
+		if len(items) > 0 && t.cmp(items[len(items)-1].key, k) >= 0 {
+			return fmt.Errorf("%w: key at index %d is not greater than preceding key", ErrNotSorted, len(items))
+		}
+		items = append(items, btreeItem[K, V]{k, v})
+	}
+	t.buildSorted(items)
+	return nil
+}
+
+func (t *LLBTree[K, V]) buildSorted(items []btreeItem[K, V]) {
+	t.shared = false
+	t.gen++
+	t.min, t.max = nil, nil
+	t.root = nil
+	if len(items) == 0 {
+		return
+	}
+
+	// Build a tree as balanced as possible; every level but the deepest is full.
+	// Coloring nodes in the deepest level red yields a valid red-black tree
+	// (unless the deepest level is also full, in which case all nodes are black)
+	// but it may contain right leaning or 4-node like red links. fix them up from bottom.
+	depth := bits.Len(uint(len(items))) - 1
+	perfect := len(items) == 1<<(depth+1)-1
+	t.setRoot(t.build(items, 0, depth, perfect))
+	t.fixupAll(t.root)
+	t.root.red = false
+
+	t.Min()
+	t.Max()
+}
+
+func (t *LLBTree[K, V]) build(items []btreeItem[K, V], depth, maxDepth int, perfect bool) *llbtreeNode[K, V] {
+	if len(items) == 0 {
+		return nil
+	}
+	// Pick upper median so that the left subtree is never smaller than the right.
+	mid := len(items) / 2
+	n := t.newNode(items[mid].key, items[mid].value)
+	n.red = depth == maxDepth && !perfect
+	n.setLeft(t.build(items[:mid], depth+1, maxDepth, perfect))
+	n.setRight(t.build(items[mid+1:], depth+1, maxDepth, perfect))
+	t.update(n)
+	return n
+}
+
+// fixupAll applies the same fix as fixup to every node in the subtree rooted at n,
+// visiting children before their parent.
+func (t *LLBTree[K, V]) fixupAll(n *llbtreeNode[K, V]) {
+	if n == nil {
+		return
+	}
+	t.fixupAll(n.left)
+	t.fixupAll(n.right)
+	if n.right.isRed() && n.left.isBlack() {
+		n = t.rotateLeft(n)
+	}
+	if n.left.isRed() && n.left.left.isRed() {
+		n = t.rotateRight(n)
+	}
+	if n.left.isRed() && n.right.isRed() {
+		n.flipColor()
+	}
+}
+
+// Split moves elements whose keys are less than key into left,
+// and the rest into right.
+// t is empty after Split returns.
+//
+// Split relinks nodes rather than copying them,
+// so it takes O(log^2 n) time.
+func (t *LLBTree[K, V]) Split(key K) (left, right *LLBTree[K, V]) {
+	t.unshare()
+	l, r := t.split(t.root, key)
+
+	t.root, t.min, t.max = nil, nil, nil
+	t.gen++
+
+	return t.adopt(l), t.adopt(r)
+}
+
+func (t *LLBTree[K, V]) split(n *llbtreeNode[K, V], key K) (l, r *llbtreeNode[K, V]) {
+	if n == nil {
+		return nil, nil
+	}
+	nl, nr := n.left.detach(), n.right.detach()
+	if t.cmp(key, n.key) <= 0 {
+		ll, lr := t.split(nl, key)
+		return ll, t.join(lr, n, nr)
+	}
+	rl, rr := t.split(nr, key)
+	return t.join(nl, n, rl), rr
+}
+
+// Join joins left and right into a single tree.
+// All keys in left must be less than every key in right,
+// otherwise Join returns an error wrapping [ErrOverlapping].
+//
+// Join relinks nodes of left and right into the returned tree in O(log n) time.
+// On success left and right are empty.
+func Join[K, V any](left, right *LLBTree[K, V]) (*LLBTree[K, V], error) {
+	if left == right {
+		return nil, fmt.Errorf("%w: left and right are same tree", ErrOverlapping)
+	}
+	if left.max != nil && right.min != nil && left.cmp(left.max.key, right.min.key) >= 0 {
+		return nil, fmt.Errorf("%w: max key of left is not less than min key of right", ErrOverlapping)
+	}
+
+	left.unshare()
+	right.unshare()
+
+	var root *llbtreeNode[K, V]
+	switch {
+	case left.root == nil:
+		root = right.root
+	case right.root == nil:
+		root = left.root
+	default:
+		// Take the least node out of right and use it as a pivot.
+		pivot := right.min
+		right.Remove(pivot.key)
+		root = left.join(left.root, left.newNode(pivot.key, pivot.value), right.root)
+	}
+
+	for _, t := range [...]*LLBTree[K, V]{left, right} {
+		t.root, t.min, t.max = nil, nil, nil
+		t.gen++
+	}
+	return left.adopt(root), nil
+}
+
+// adopt returns a new tree whose root is n.
+func (t *LLBTree[K, V]) adopt(n *llbtreeNode[K, V]) *LLBTree[K, V] {
+	adopted := &LLBTree[K, V]{
+		cmp:        t.cmp,
+		augment:    t.augment,
+		keyCodec:   t.keyCodec,
+		valueCodec: t.valueCodec,
+		alloc:      llbtreeAlloc[K, V]{param: t.alloc.param},
+	}
+	adopted.setRoot(n)
+	adopted.Min()
+	adopted.Max()
+	return adopted
+}
+
+// join returns the root of a tree containing all nodes of l, p and r,
+// where l and r are roots of valid trees (thus black) and p is a single node
+// whose key sits between keys of l and r.
+func (t *LLBTree[K, V]) join(l, p, r *llbtreeNode[K, V]) *llbtreeNode[K, V] {
+	p.parent, p.left, p.right = nil, nil, nil
+	p.red = false
+
+	tmp := &LLBTree[K, V]{cmp: t.cmp, augment: t.augment}
+
+	bl, br := l.blackHeight(), r.blackHeight()
+	switch {
+	case bl == br:
+		p.setLeft(l)
+		p.setRight(r)
+		t.update(p)
+		return p
+	case bl > br:
+		// Right children are always black. Descend the right spine of l
+		// until reaching the node whose black height is equal to r,
+		// then put p in there as a red node having the node and r as children.
+		tmp.setRoot(l)
+		parent, c := l, l.right
+		for h := bl - 1; h > br; h-- {
+			parent, c = c, c.right
+		}
+		p.setLeft(c)
+		p.setRight(r)
+		p.red = true
+		parent.setRight(p)
+	default:
+		tmp.setRoot(r)
+		var parent *llbtreeNode[K, V]
+		c := r
+		for h := br; !(c.isBlack() && h == bl); c = c.left {
+			if c.isBlack() {
+				h--
+			}
+			parent = c
+		}
+		p.setLeft(l)
+		p.setRight(c)
+		p.red = true
+		parent.setLeft(p)
+	}
+	tmp.fixup(p)
+	return tmp.root
+}
+
+// detach detaches n from its parent and makes it a black root.
+func (n *llbtreeNode[K, V]) detach() *llbtreeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	n.parent = nil
+	n.red = false
+	return n
+}
+
+func (n *llbtreeNode[K, V]) blackHeight() int {
+	var h int
+	for ; n != nil; n = n.left {
+		if n.isBlack() {
+			h++
+		}
+	}
+	return h
+}
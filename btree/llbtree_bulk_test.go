@@ -0,0 +1,137 @@
+package btree
+
+import (
+	"errors"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func sortedItems(n int) []keyValue[int, int] {
+	kv := make([]keyValue[int, int], n)
+	for i := range n {
+		kv[i] = keyValue[int, int]{i * 2, i}
+	}
+	return kv
+}
+
+func TestLLBTree_BuildSorted(t *testing.T) {
+	for n := range 130 {
+		expected := sortedItems(n)
+		tree := NewLLBTreeOrdered[int, int]()
+		tree.Insert(-1, -1)
+		if err := tree.BuildSorted(values2(expected)); err != nil {
+			t.Fatalf("n = %d: must not return error: %v", n, err)
+		}
+		assertValid(t, tree)
+		if collected := collect2(t, func(i int) int { return i }, tree.All()); !slices.Equal(expected, collected) {
+			t.Fatalf("n = %d: not as expected = %#v", n, collected)
+		}
+		if tree.Len() != n {
+			t.Fatalf("n = %d: wrong len %d", n, tree.Len())
+		}
+		// the built tree must work just like trees built by Insert.
+		for _, kv := range expected {
+			tree.Remove(kv.K)
+			assertValid(t, tree)
+		}
+	}
+
+	tree := NewLLBTreeOrdered[int, int]()
+	tree.Insert(-1, -1)
+	for _, bad := range [][]keyValue[int, int]{
+		{{1, 1}, {0, 0}},
+		{{0, 0}, {1, 1}, {1, 1}},
+	} {
+		err := tree.BuildSorted(values2(bad))
+		if !errors.Is(err, ErrNotSorted) {
+			t.Fatalf("must be ErrNotSorted but is %v", err)
+		}
+		if k, _, _ := tree.Min(); tree.Len() != 1 || k != -1 {
+			t.Fatalf("tree is modified")
+		}
+	}
+}
+
+func TestLLBTree_Split_Join(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 200 {
+		model := map[int]int{}
+		tree := NewLLBTreeOrdered[int, int]()
+		for range r.IntN(100) {
+			k := r.IntN(200)
+			tree.Insert(k, k)
+			model[k] = k
+		}
+		keys := slices.Sorted(maps.Keys(model))
+		pivot := r.IntN(220) - 10
+
+		left, right := tree.Split(pivot)
+		if tree.Len() != 0 {
+			t.Fatalf("split tree must be empty")
+		}
+		assertValid(t, left)
+		assertValid(t, right)
+
+		idx, _ := slices.BinarySearch(keys, pivot)
+		if l := slices.Collect(omitL(left.All())); !slices.Equal(keys[:idx], l) {
+			t.Fatalf("wrong left: pivot = %d, expected = %v, actual = %v", pivot, keys[:idx], l)
+		}
+		if r := slices.Collect(omitL(right.All())); !slices.Equal(keys[idx:], r) {
+			t.Fatalf("wrong right: pivot = %d, expected = %v, actual = %v", pivot, keys[idx:], r)
+		}
+
+		joined, err := Join(left, right)
+		if err != nil {
+			t.Fatalf("must not return error: %v", err)
+		}
+		if left.Len() != 0 || right.Len() != 0 {
+			t.Fatalf("joined trees must be empty")
+		}
+		assertValid(t, joined)
+		if j := slices.Collect(omitL(joined.All())); !slices.Equal(keys, j) {
+			t.Fatalf("wrong joined: expected = %v, actual = %v", keys, j)
+		}
+	}
+}
+
+func TestJoin_different_heights(t *testing.T) {
+	for _, sizes := range [][2]int{{1, 100}, {100, 1}, {3, 1000}, {1000, 3}, {0, 5}, {5, 0}} {
+		left, right := NewLLBTreeOrdered[int, int](), NewLLBTreeOrdered[int, int]()
+		for i := range sizes[0] {
+			left.Insert(i, i)
+		}
+		for i := range sizes[1] {
+			right.Insert(sizes[0]+i, i)
+		}
+		joined, err := Join(left, right)
+		if err != nil {
+			t.Fatalf("must not return error: %v", err)
+		}
+		assertValid(t, joined)
+		if joined.Len() != sizes[0]+sizes[1] {
+			t.Fatalf("wrong len: %d", joined.Len())
+		}
+		for i, k := range enumerate(omitL(joined.All())) {
+			if i != k {
+				t.Fatalf("wrong key at %d: %d", i, k)
+			}
+		}
+	}
+}
+
+func TestJoin_overlapping(t *testing.T) {
+	left, right := NewLLBTreeOrdered[int, int](), NewLLBTreeOrdered[int, int]()
+	left.InsertSeq(values2(sortedItems(5)))
+	right.InsertSeq(values2(sortedItems(5)[4:]))
+	if _, err := Join(left, right); !errors.Is(err, ErrOverlapping) {
+		t.Fatalf("must be ErrOverlapping but is %v", err)
+	}
+	if _, err := Join(left, left); !errors.Is(err, ErrOverlapping) {
+		t.Fatalf("must be ErrOverlapping but is %v", err)
+	}
+	if left.Len() != 5 || right.Len() != 1 {
+		t.Fatalf("trees are modified")
+	}
+}
@@ -0,0 +1,162 @@
+package btree
+
+// Cursor points at an element of an [LLBTree].
+//
+// Unlike iterators returned from [LLBTree.All] or [LLBTree.Scan],
+// a Cursor can be paused, moved in both directions,
+// and can update or remove the element it points at.
+//
+// A Cursor stays usable across any mutation of the tree.
+// If the element it points at is removed, the cursor becomes invalid
+// but Next and Prev still move it to the neighbors of the removed key.
+type Cursor[K, V any] struct {
+	t   *LLBTree[K, V]
+	n   *llbtreeNode[K, V]
+	gen uint64
+	// key and value are copied from n whenever c observes it,
+	// since n may be reused for another element once removed.
+	key   K
+	value V
+}
+
+// Seek returns a Cursor pointing at the element with the least key greater than or equal to key.
+// The returned cursor is invalid if there is no such element.
+func (t *LLBTree[K, V]) Seek(key K) *Cursor[K, V] {
+	return t.cursor(t.ceiling(key))
+}
+
+// First returns a Cursor pointing at the element with the least key.
+// The returned cursor is invalid if t is empty.
+func (t *LLBTree[K, V]) First() *Cursor[K, V] {
+	if t.root == nil {
+		return t.cursor(nil)
+	}
+	return t.cursor(*t.root.leftmost(&t.root))
+}
+
+// Last returns a Cursor pointing at the element with the greatest key.
+// The returned cursor is invalid if t is empty.
+func (t *LLBTree[K, V]) Last() *Cursor[K, V] {
+	if t.root == nil {
+		return t.cursor(nil)
+	}
+	return t.cursor(*t.root.rightmost(&t.root))
+}
+
+func (t *LLBTree[K, V]) cursor(n *llbtreeNode[K, V]) *Cursor[K, V] {
+	c := &Cursor[K, V]{
+		t:   t,
+		gen: t.gen,
+	}
+	c.set(n)
+	return c
+}
+
+func (c *Cursor[K, V]) set(n *llbtreeNode[K, V]) {
+	c.n = n
+	if n != nil {
+		c.key, c.value = n.key, n.value
+	}
+}
+
+// current returns the node c points at, or nil if it has been removed.
+func (c *Cursor[K, V]) current() *llbtreeNode[K, V] {
+	if c.n == nil {
+		return nil
+	}
+	if c.gen != c.t.gen {
+		// nodes are replaced by unshare or reused; find the counterpart.
+		c.gen = c.t.gen
+		if n := c.t.get(c.key); n != nil {
+			c.n = n
+		} else if !c.n.deleted || c.t.alloc.param.reuse {
+			c.n = &llbtreeNode[K, V]{key: c.key, value: c.value, deleted: true}
+		}
+	}
+	c.value = c.n.value
+	if c.n.deleted {
+		return nil
+	}
+	return c.n
+}
+
+// Valid reports whether c points at an element in the tree.
+func (c *Cursor[K, V]) Valid() bool {
+	return c.current() != nil
+}
+
+// Key returns the key of the element c points at.
+// If the element has been removed, Key returns the key it had.
+// Key returns the zero value if c has moved past either end.
+func (c *Cursor[K, V]) Key() K {
+	if c.n == nil {
+		return *new(K)
+	}
+	return c.key
+}
+
+// Value returns the value of the element c points at.
+// If the element has been removed, Value returns the value it had.
+// Value returns the zero value if c has moved past either end.
+func (c *Cursor[K, V]) Value() V {
+	if c.n == nil {
+		return *new(V)
+	}
+	c.current()
+	return c.value
+}
+
+// Next moves c to the element with the next greater key and reports whether c is valid.
+// Once c moves past the last element, it stays invalid.
+func (c *Cursor[K, V]) Next() bool {
+	if c.n == nil {
+		return false
+	}
+	if n := c.current(); n != nil {
+		c.set(n.next(&c.t.root))
+	} else {
+		c.set(c.t.root.nextAfter(c.t.cmp, &c.t.root, c.key))
+	}
+	return c.n != nil
+}
+
+// Prev moves c to the element with the next smaller key and reports whether c is valid.
+// Once c moves past the first element, it stays invalid.
+func (c *Cursor[K, V]) Prev() bool {
+	if c.n == nil {
+		return false
+	}
+	if n := c.current(); n != nil {
+		c.set(n.prev(&c.t.root))
+	} else {
+		c.set(c.t.root.prevBefore(c.t.cmp, &c.t.root, c.key))
+	}
+	return c.n != nil
+}
+
+// SetValue replaces the value of the element c points at.
+// It reports false without doing anything if c is not valid.
+func (c *Cursor[K, V]) SetValue(value V) bool {
+	if c.current() == nil {
+		return false
+	}
+	c.t.unshare()
+	c.current().value = value
+	c.value = value
+	return true
+}
+
+// Delete removes the element c points at from the tree
+// and moves c to the element with the next greater key, if any.
+// It reports false without doing anything if c is not valid.
+func (c *Cursor[K, V]) Delete() bool {
+	n := c.current()
+	if n == nil {
+		return false
+	}
+	key := n.key
+	c.t.Remove(key)
+	c.gen = c.t.gen
+	c.set(c.t.root.nextAfter(c.t.cmp, &c.t.root, key))
+	return true
+}
@@ -0,0 +1,154 @@
+package btree
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func collectCursor[K, V any](c *Cursor[K, V], forward bool) []keyValue[K, V] {
+	var kv []keyValue[K, V]
+	for ok := c.Valid(); ok; {
+		kv = append(kv, keyValue[K, V]{c.Key(), c.Value()})
+		if forward {
+			ok = c.Next()
+		} else {
+			ok = c.Prev()
+		}
+	}
+	return kv
+}
+
+func TestLLBTree_Cursor(t *testing.T) {
+	runInAllPattern2(
+		t,
+		llbTreeExpected,
+		func(t *testing.T, pattern []keyValue[int, string]) {
+			tree := NewLLBTreeOrdered[int, string]()
+			tree.InsertSeq(values2(pattern))
+
+			if collected := collectCursor(tree.First(), true); !slices.Equal(llbTreeExpected, collected) {
+				t.Fatalf("not as expected = %#v", collected)
+			}
+			expected := slices.Clone(llbTreeExpected)
+			slices.Reverse(expected)
+			if collected := collectCursor(tree.Last(), false); !slices.Equal(expected, collected) {
+				t.Fatalf("not as expected = %#v", collected)
+			}
+
+			for _, tc := range []struct {
+				key      int
+				valid    bool
+				expected int
+			}{
+				{-1, true, 0},
+				{0, true, 0},
+				{1, true, 2},
+				{5, true, 5},
+				{6, true, 7},
+				{8, false, 0},
+			} {
+				c := tree.Seek(tc.key)
+				if c.Valid() != tc.valid || c.Key() != tc.expected {
+					t.Errorf("Seek(%d) = (%t, %d), want (%t, %d)", tc.key, c.Valid(), c.Key(), tc.valid, tc.expected)
+				}
+			}
+
+			c := tree.Seek(4)
+			if !c.Next() || c.Key() != 5 || !c.Prev() || !c.Prev() || c.Key() != 2 {
+				t.Errorf("wrong position: %d", c.Key())
+			}
+			if !c.SetValue("corge") {
+				t.Errorf("SetValue must succeed")
+			}
+			if v, _ := tree.Get(2); v != "corge" {
+				t.Errorf("value is not updated: %q", v)
+			}
+		},
+	)
+}
+
+func TestLLBTree_Cursor_Delete(t *testing.T) {
+	runInAllPattern2(
+		t,
+		llbTreeExpected,
+		func(t *testing.T, pattern []keyValue[int, string]) {
+			for _, kv := range llbTreeExpected {
+				t.Run(fmt.Sprintf("delete %d", kv.K), func(t *testing.T) {
+					tree := NewLLBTreeOrdered[int, string]()
+					tree.InsertSeq(values2(pattern))
+
+					c := tree.First()
+					var collected []keyValue[int, string]
+					for c.Valid() {
+						collected = append(collected, keyValue[int, string]{c.Key(), c.Value()})
+						if c.Key() == kv.K {
+							if !c.Delete() {
+								t.Fatalf("Delete must succeed")
+							}
+							continue
+						}
+						c.Next()
+					}
+					if !slices.Equal(llbTreeExpected, collected) {
+						t.Fatalf("not as expected = %#v", collected)
+					}
+					expected := slices.DeleteFunc(slices.Clone(llbTreeExpected), func(e keyValue[int, string]) bool { return e.K == kv.K })
+					if collected := collect2(t, func(i int) int { return i }, tree.All()); !slices.Equal(expected, collected) {
+						t.Fatalf("not as expected = %#v", collected)
+					}
+				})
+			}
+		},
+	)
+
+	tree := NewLLBTreeOrdered[int, string]()
+	tree.InsertSeq(values2(llbTreeExpected))
+	c := tree.First()
+	for c.Valid() {
+		c.Delete()
+	}
+	if tree.Len() != 0 {
+		t.Fatalf("must be empty but len = %d", tree.Len())
+	}
+}
+
+func TestLLBTree_Cursor_removed_externally(t *testing.T) {
+	tree := NewLLBTreeOrdered[int, string]()
+	tree.InsertSeq(values2(llbTreeExpected))
+
+	c := tree.Seek(4)
+	tree.Remove(4)
+	if c.Valid() {
+		t.Fatalf("must be invalid")
+	}
+	if c.Key() != 4 || c.Value() != "baz" {
+		t.Fatalf("must return removed element: %d, %q", c.Key(), c.Value())
+	}
+	if c.SetValue("corge") || c.Delete() {
+		t.Fatalf("must not succeed")
+	}
+	if !c.Next() || c.Key() != 5 {
+		t.Fatalf("wrong position: %d", c.Key())
+	}
+
+	_ = tree.Snapshot()
+	tree.Remove(5)
+	if c.Valid() {
+		t.Fatalf("must be invalid")
+	}
+	if !c.Prev() || c.Key() != 2 {
+		t.Fatalf("wrong position: %d", c.Key())
+	}
+
+	snapshot := tree.Snapshot()
+	if !c.SetValue("corge") {
+		t.Fatalf("SetValue must succeed")
+	}
+	if v, _ := tree.Get(2); v != "corge" {
+		t.Fatalf("value is not updated: %q", v)
+	}
+	if v, _ := snapshot.Get(2); v != "bar" {
+		t.Fatalf("snapshot is modified: %q", v)
+	}
+}
@@ -0,0 +1,257 @@
+package btree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	_ json.Marshaler             = (*LLBTree[int, int])(nil)
+	_ json.Unmarshaler           = (*LLBTree[int, int])(nil)
+	_ encoding.BinaryMarshaler   = (*LLBTree[int, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*LLBTree[int, int])(nil)
+)
+
+// binaryFormatVersion is the first byte of the binary encoding of LLBTree.
+const binaryFormatVersion = 1
+
+// SetCodec sets codecs used by MarshalBinary and UnmarshalBinary.
+// If either codec is nil, [JSONCodec] is used for it.
+func (t *LLBTree[K, V]) SetCodec(key Codec[K], value Codec[V]) {
+	t.keyCodec, t.valueCodec = key, value
+}
+
+func (t *LLBTree[K, V]) codecs() (Codec[K], Codec[V]) {
+	key, value := t.keyCodec, t.valueCodec
+	if key == nil {
+		key = JSONCodec[K]{}
+	}
+	if value == nil {
+		value = JSONCodec[V]{}
+	}
+	return key, value
+}
+
+// MarshalJSON implements [json.Marshaler].
+// t is encoded as an array of [key, value] arrays in ascending order of keys.
+func (t *LLBTree[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.EncodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// EncodeJSON writes the same JSON encoding as MarshalJSON to w, followed by a newline,
+// without building the whole encoding in memory.
+func (t *LLBTree[K, V]) EncodeJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	_ = bw.WriteByte('[')
+	first := true
+	for k, v := range t.All() {
+		if !first {
+			_ = bw.WriteByte(',')
+		}
+		first = false
+		for i, x := range [...]any{k, v} {
+			b, err := json.Marshal(x)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				_ = bw.WriteByte('[')
+			} else {
+				_ = bw.WriteByte(',')
+			}
+			_, _ = bw.Write(b)
+		}
+		_ = bw.WriteByte(']')
+	}
+	_, _ = bw.WriteString("]\n")
+	return bw.Flush()
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It replaces the content of t with pairs decoded from data, the format MarshalJSON produces.
+// Keys must be in strictly ascending order, otherwise it returns an error wrapping [ErrNotSorted].
+// t is left unchanged if UnmarshalJSON returns an error.
+// As is conventional, JSON null is a no-op.
+//
+// t must be created by a constructor, e.g. [NewLLBTree], since the zero value has no comparator.
+func (t *LLBTree[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	return t.DecodeJSON(bytes.NewReader(data))
+}
+
+// DecodeJSON is like UnmarshalJSON but reads a JSON array from r.
+// Pairs are decoded one by one from the stream and built into a tree in O(n) time
+// rather than being inserted one by one.
+func (t *LLBTree[K, V]) DecodeJSON(r io.Reader) error {
+	if t.cmp == nil {
+		return errors.New("nil comparator: create tree by a constructor")
+	}
+
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	var items []btreeItem[K, V]
+	for dec.More() {
+		if err := expectDelim(dec, '['); err != nil {
+			return fmt.Errorf("pair at index %d: %w", len(items), err)
+		}
+		var item btreeItem[K, V]
+		if err := dec.Decode(&item.key); err != nil {
+			return fmt.Errorf("key at index %d: %w", len(items), err)
+		}
+		if err := dec.Decode(&item.value); err != nil {
+			return fmt.Errorf("value at index %d: %w", len(items), err)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return fmt.Errorf("pair at index %d: %w", len(items), err)
+		}
+		if len(items) > 0 && t.cmp(items[len(items)-1].key, item.key) >= 0 {
+			return fmt.Errorf("%w: key at index %d is not greater than preceding key", ErrNotSorted, len(items))
+		}
+		items = append(items, item)
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return err
+	}
+	t.buildSorted(items)
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %q but got %v", delim, tok)
+	}
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler],
+// encoding keys and values by codecs set by SetCodec.
+//
+// The encoding is a version byte, followed by the number of elements as a uvarint,
+// followed by keys and values in ascending order of keys,
+// each prefixed with its length as a uvarint.
+func (t *LLBTree[K, V]) MarshalBinary() ([]byte, error) {
+	return t.AppendBinary(nil)
+}
+
+// AppendBinary appends the encoding MarshalBinary produces to b.
+func (t *LLBTree[K, V]) AppendBinary(b []byte) ([]byte, error) {
+	keyCodec, valueCodec := t.codecs()
+	b = append(b, binaryFormatVersion)
+	b = binary.AppendUvarint(b, uint64(t.Len()))
+	var (
+		elem []byte
+		err  error
+	)
+	for k, v := range t.All() {
+		elem, err = keyCodec.AppendEncode(elem[:0], k)
+		if err != nil {
+			return b, fmt.Errorf("encoding key %v: %w", k, err)
+		}
+		b = append(binary.AppendUvarint(b, uint64(len(elem))), elem...)
+		elem, err = valueCodec.AppendEncode(elem[:0], v)
+		if err != nil {
+			return b, fmt.Errorf("encoding value for key %v: %w", k, err)
+		}
+		b = append(binary.AppendUvarint(b, uint64(len(elem))), elem...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+// It replaces the content of t with elements decoded from data,
+// the format MarshalBinary produces, by codecs set by SetCodec.
+// Keys must be in strictly ascending order, otherwise it returns an error wrapping [ErrNotSorted].
+// t is left unchanged if UnmarshalBinary returns an error.
+func (t *LLBTree[K, V]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if err := t.decodeBinary(r); err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("%d bytes of trailing data", r.Len())
+	}
+	return nil
+}
+
+func (t *LLBTree[K, V]) decodeBinary(r *bytes.Reader) error {
+	if t.cmp == nil {
+		return errors.New("nil comparator: create tree by a constructor")
+	}
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("reading version: %w", noEOF(err))
+	}
+	if version != binaryFormatVersion {
+		return fmt.Errorf("unknown format version %d", version)
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("reading length: %w", noEOF(err))
+	}
+
+	keyCodec, valueCodec := t.codecs()
+	// count comes from the input; do not trust it to preallocate.
+	items := make([]btreeItem[K, V], 0, min(count, 1024))
+	var buf bytes.Buffer
+	readElem := func() ([]byte, error) {
+		l, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, noEOF(err)
+		}
+		buf.Reset()
+		// CopyN, rather than preallocating l bytes, keeps corrupt lengths from exhausting memory.
+		if _, err := io.CopyN(&buf, r, int64(l)); err != nil {
+			return nil, noEOF(err)
+		}
+		return buf.Bytes(), nil
+	}
+	for i := range count {
+		var item btreeItem[K, V]
+		b, err := readElem()
+		if err == nil {
+			item.key, err = keyCodec.Decode(b)
+		}
+		if err != nil {
+			return fmt.Errorf("key at index %d: %w", i, err)
+		}
+		b, err = readElem()
+		if err == nil {
+			item.value, err = valueCodec.Decode(b)
+		}
+		if err != nil {
+			return fmt.Errorf("value at index %d: %w", i, err)
+		}
+		if len(items) > 0 && t.cmp(items[len(items)-1].key, item.key) >= 0 {
+			return fmt.Errorf("%w: key at index %d is not greater than preceding key", ErrNotSorted, i)
+		}
+		items = append(items, item)
+	}
+	t.buildSorted(items)
+	return nil
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
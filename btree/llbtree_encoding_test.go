@@ -0,0 +1,217 @@
+package btree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLLBTree_JSON(t *testing.T) {
+	tree := NewLLBTreeOrdered[string, int]()
+	tree.InsertSeq(maps.All(map[string]int{"foo": 1, "bar": 2, "baz": 3}))
+
+	b, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[["bar",2],["baz",3],["foo",1]]`; string(b) != want {
+		t.Fatalf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	decoded := NewLLBTreeOrdered[string, int]()
+	decoded.Insert("qux", 4)
+	if err := json.Unmarshal(b, decoded); err != nil {
+		t.Fatal(err)
+	}
+	assertValid(t, decoded)
+	if got, want := collectKeyValue(decoded.All()), collectKeyValue(tree.All()); !slices.Equal(got, want) {
+		t.Fatalf("decoded = %v, want %v", got, want)
+	}
+
+	empty := NewLLBTreeOrdered[string, int]()
+	if b, err := json.Marshal(empty); err != nil || string(b) != "[]" {
+		t.Fatalf("json.Marshal(empty) = (%s, %v), want ([], nil)", b, err)
+	}
+	if err := json.Unmarshal([]byte("[]"), decoded); err != nil || decoded.Len() != 0 {
+		t.Fatalf("json.Unmarshal([]) = %v, Len() = %d", err, decoded.Len())
+	}
+
+	// null is a no-op.
+	for _, unmarshal := range []func([]byte, *LLBTree[string, int]) error{
+		func(b []byte, t *LLBTree[string, int]) error { return json.Unmarshal(b, t) },
+		func(b []byte, t *LLBTree[string, int]) error { return t.UnmarshalJSON(b) },
+	} {
+		if err := unmarshal([]byte(" null "), tree); err != nil {
+			t.Fatalf("unmarshal(null) = %v", err)
+		}
+		if got, want := collectKeyValue(tree.All()), []keyValue[string, int]{{"bar", 2}, {"baz", 3}, {"foo", 1}}; !slices.Equal(got, want) {
+			t.Fatalf("unmarshal(null) modified tree: %v", got)
+		}
+	}
+	var zero LLBTree[string, int]
+	if err := zero.UnmarshalJSON([]byte("null")); err != nil {
+		t.Fatalf("UnmarshalJSON(null) into zero value tree = %v", err)
+	}
+}
+
+func TestLLBTree_JSON_error(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		is    error
+	}{
+		{`{}`, nil},
+		{`[[1,1],[0,0]]`, ErrNotSorted},
+		{`[[1,1],[1,1]]`, ErrNotSorted},
+		{`[[1]]`, nil},
+		{`[[1,1,1]]`, nil},
+		{`[["1",1]]`, nil},
+		{`[1,1]`, nil},
+	} {
+		tree := NewLLBTreeOrdered[int, int]()
+		tree.Insert(5, 5)
+		err := json.Unmarshal([]byte(tc.input), tree)
+		if err == nil {
+			t.Errorf("json.Unmarshal(%s) should fail", tc.input)
+			continue
+		}
+		if tc.is != nil && !errors.Is(err, tc.is) {
+			t.Errorf("json.Unmarshal(%s) = %v, want error wrapping %v", tc.input, err, tc.is)
+		}
+		if got := collectKeyValue(tree.All()); !slices.Equal(got, []keyValue[int, int]{{5, 5}}) {
+			t.Errorf("json.Unmarshal(%s) modified tree on error: %v", tc.input, got)
+		}
+	}
+
+	var zero LLBTree[int, int]
+	if err := json.Unmarshal([]byte("[]"), &zero); err == nil {
+		t.Error("json.Unmarshal into zero value tree should fail")
+	}
+}
+
+func TestLLBTree_DecodeJSON_stream(t *testing.T) {
+	var buf bytes.Buffer
+	var trees []*LLBTree[int, string]
+	for i := range 3 {
+		tree := NewLLBTreeOrdered[int, string]()
+		for j := range i * 100 {
+			tree.Insert(j, strings.Repeat("a", j%7))
+		}
+		trees = append(trees, tree)
+		if err := tree.EncodeJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dec := json.NewDecoder(&buf)
+	for i, tree := range trees {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			t.Fatal(err)
+		}
+		decoded := NewLLBTreeOrdered[int, string]()
+		if err := decoded.DecodeJSON(bytes.NewReader(raw)); err != nil {
+			t.Fatal(err)
+		}
+		assertValid(t, decoded)
+		if got, want := collectKeyValue(decoded.All()), collectKeyValue(tree.All()); !slices.Equal(got, want) {
+			t.Fatalf("tree %d: decoded = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestLLBTree_Binary(t *testing.T) {
+	t.Run("default codec", func(t *testing.T) {
+		tree := NewLLBTreeOrdered[string, []int]()
+		tree.Insert("foo", []int{1, 2})
+		tree.Insert("bar", nil)
+		testBinaryRoundTrip(t, tree, NewLLBTreeOrdered[string, []int](), slices.Equal)
+	})
+	t.Run("int codec", func(t *testing.T) {
+		tree := NewLLBTreeOrdered[int64, string]()
+		tree.SetCodec(IntCodec[int64]{}, StringCodec[string]{})
+		for i := range int64(10000) {
+			tree.Insert(i*i*(i%3-1), strings.Repeat("x", int(i%5)))
+		}
+		decoded := NewLLBTreeOrdered[int64, string]()
+		decoded.SetCodec(IntCodec[int64]{}, StringCodec[string]{})
+		testBinaryRoundTrip(t, tree, decoded, func(l, r string) bool { return l == r })
+	})
+	t.Run("set", func(t *testing.T) {
+		set := NewSetOrdered[uint16]()
+		set.AddSeq(slices.Values([]uint16{3, 1, 65535}))
+		set.Tree().SetCodec(UintCodec[uint16]{}, EmptyCodec{})
+		decoded := NewSetOrdered[uint16]()
+		decoded.Tree().SetCodec(UintCodec[uint16]{}, EmptyCodec{})
+		testBinaryRoundTrip(t, set.Tree(), decoded.Tree(), func(l, r struct{}) bool { return true })
+	})
+}
+
+func testBinaryRoundTrip[K comparable, V any](t *testing.T, tree, decoded *LLBTree[K, V], eq func(l, r V) bool) {
+	t.Helper()
+
+	equal := func(other *LLBTree[K, V]) bool {
+		return slices.EqualFunc(
+			collectKeyValue(tree.All()),
+			collectKeyValue(other.All()),
+			func(l, r keyValue[K, V]) bool { return l.K == r.K && eq(l.V, r.V) },
+		)
+	}
+
+	b, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	assertValid(t, decoded)
+	if !equal(decoded) {
+		t.Fatalf("UnmarshalBinary decoded different tree")
+	}
+
+	// truncated inputs must be rejected.
+	for i := 0; i < len(b); i += max(1, len(b)/200) {
+		if err := decoded.UnmarshalBinary(b[:i]); err == nil {
+			t.Fatalf("UnmarshalBinary should fail for input truncated to %d bytes", i)
+		}
+	}
+	if err := decoded.UnmarshalBinary(append(b, 0)); err == nil {
+		t.Fatal("UnmarshalBinary should fail for input with trailing data")
+	}
+	if !equal(decoded) {
+		t.Fatalf("failed decoding modified tree")
+	}
+}
+
+func TestLLBTree_Binary_not_sorted(t *testing.T) {
+	tree := NewLLBTreeOrdered[int, int]()
+	tree.SetCodec(IntCodec[int]{}, IntCodec[int]{})
+	// version, count, (len, key, len, value)...
+	err := tree.UnmarshalBinary([]byte{1, 2, 1, 4, 1, 0, 1, 2, 1, 0})
+	if !errors.Is(err, ErrNotSorted) {
+		t.Fatalf("UnmarshalBinary() = %v, want error wrapping ErrNotSorted", err)
+	}
+}
+
+func TestCodec_overflow(t *testing.T) {
+	b, _ := IntCodec[int]{}.AppendEncode(nil, 300)
+	if _, err := (IntCodec[int8]{}).Decode(b); err == nil {
+		t.Error("IntCodec[int8] should fail to decode 300")
+	}
+	b, _ = UintCodec[uint]{}.AppendEncode(nil, 256)
+	if _, err := (UintCodec[uint8]{}).Decode(b); err == nil {
+		t.Error("UintCodec[uint8] should fail to decode 256")
+	}
+	if _, err := (EmptyCodec{}).Decode([]byte{0}); err == nil {
+		t.Error("EmptyCodec should fail to decode non empty input")
+	}
+}
+
+func collectKeyValue[K, V any](seq iter.Seq2[K, V]) []keyValue[K, V] {
+	return collect2[K, V, int](nil, nil, seq)
+}
@@ -0,0 +1,145 @@
+package btree
+
+import (
+	"cmp"
+	"iter"
+	"math"
+)
+
+// MultiLLBTree is a variant of [LLBTree] allowing duplicate keys.
+//
+// Elements with equal keys are kept in insertion order:
+// iterators in ascending order yield them from the oldest to the newest,
+// and iterators in descending order from the newest to the oldest.
+//
+// Like LLBTree, MultiLLBTree is not safe for concurrent use.
+type MultiLLBTree[K, V any] struct {
+	cmp  func(l, r K) int
+	seq  uint64
+	tree *LLBTree[multiKey[K], V]
+}
+
+// multiKey orders duplicate keys by insertion order.
+type multiKey[K any] struct {
+	key K
+	seq uint64
+}
+
+func NewMultiLLBTree[K, V any](cmp func(l, r K) int) *MultiLLBTree[K, V] {
+	return &MultiLLBTree[K, V]{
+		cmp: cmp,
+		tree: NewLLBTree[multiKey[K], V](func(l, r multiKey[K]) int {
+			if c := cmp(l.key, r.key); c != 0 {
+				return c
+			}
+			switch {
+			case l.seq < r.seq:
+				return -1
+			case l.seq > r.seq:
+				return 1
+			}
+			return 0
+		}),
+	}
+}
+
+func NewMultiLLBTreeOrdered[K cmp.Ordered, V any]() *MultiLLBTree[K, V] {
+	return NewMultiLLBTree[K, V](cmp.Compare[K])
+}
+
+// firstDup and lastDup bound every element whose key is key.
+func firstDup[K any](key K) multiKey[K] { return multiKey[K]{key, 0} }
+func lastDup[K any](key K) multiKey[K]  { return multiKey[K]{key, math.MaxUint64} }
+
+// InsertDup adds key-value pair to t.
+// Unlike [LLBTree.Insert], it never overwrites elements already mapped to key.
+func (t *MultiLLBTree[K, V]) InsertDup(key K, value V) {
+	// seq starts from 1 so that 0 is available to first.
+	t.seq++
+	t.tree.Insert(multiKey[K]{key, t.seq}, value)
+}
+
+// Get returns the oldest value mapped to key.
+func (t *MultiLLBTree[K, V]) Get(key K) (value V, ok bool) {
+	k, v, ok := t.tree.Ceiling(firstDup(key))
+	if !ok || t.cmp(k.key, key) != 0 {
+		return value, false
+	}
+	return v, true
+}
+
+// GetAll returns an iterator over values mapped to key, in insertion order.
+func (t *MultiLLBTree[K, V]) GetAll(key K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range t.tree.Scan(firstDup(key), lastDup(key)) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Count returns the number of values mapped to key in O(log n) time.
+func (t *MultiLLBTree[K, V]) Count(key K) int {
+	return t.tree.Rank(lastDup(key)) - t.tree.Rank(firstDup(key))
+}
+
+// RemoveOne removes the oldest value mapped to key.
+func (t *MultiLLBTree[K, V]) RemoveOne(key K) (removed bool) {
+	k, _, ok := t.tree.Ceiling(firstDup(key))
+	if !ok || t.cmp(k.key, key) != 0 {
+		return false
+	}
+	return t.tree.Remove(k)
+}
+
+// RemoveAll removes every value mapped to key and returns the number of removed values.
+func (t *MultiLLBTree[K, V]) RemoveAll(key K) (removed int) {
+	for t.RemoveOne(key) {
+		removed++
+	}
+	return removed
+}
+
+// Len returns the number of elements in t, counting duplicates.
+func (t *MultiLLBTree[K, V]) Len() int {
+	return t.tree.Len()
+}
+
+// Min returns the oldest element among those with the least key.
+func (t *MultiLLBTree[K, V]) Min() (key K, value V, ok bool) {
+	k, v, ok := t.tree.Min()
+	return k.key, v, ok
+}
+
+// Max returns the newest element among those with the greatest key.
+func (t *MultiLLBTree[K, V]) Max() (key K, value V, ok bool) {
+	k, v, ok := t.tree.Max()
+	return k.key, v, ok
+}
+
+func (t *MultiLLBTree[K, V]) All() iter.Seq2[K, V] {
+	return unwrapMultiKey(t.tree.All())
+}
+
+func (t *MultiLLBTree[K, V]) Backward() iter.Seq2[K, V] {
+	return unwrapMultiKey(t.tree.Backward())
+}
+
+// Scan is like [LLBTree.Scan] but yields every duplicate of keys between lo and hi.
+func (t *MultiLLBTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
+	if t.cmp(lo, hi) <= 0 {
+		return unwrapMultiKey(t.tree.Scan(firstDup(lo), lastDup(hi)))
+	}
+	return unwrapMultiKey(t.tree.Scan(lastDup(lo), firstDup(hi)))
+}
+
+func unwrapMultiKey[K, V any](seq iter.Seq2[multiKey[K], V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if !yield(k.key, v) {
+				return
+			}
+		}
+	}
+}
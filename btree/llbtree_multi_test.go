@@ -0,0 +1,72 @@
+package btree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiLLBTree(t *testing.T) {
+	tree := NewMultiLLBTreeOrdered[int, string]()
+	for _, kv := range []keyValue[int, string]{
+		{2, "a"}, {1, "b"}, {2, "c"}, {3, "d"}, {2, "e"}, {1, "f"},
+	} {
+		tree.InsertDup(kv.K, kv.V)
+	}
+
+	if l := tree.Len(); l != 6 {
+		t.Fatalf("Len() = %d, want 6", l)
+	}
+	for key, want := range map[int][]string{0: nil, 1: {"b", "f"}, 2: {"a", "c", "e"}, 3: {"d"}} {
+		if got := slices.Collect(tree.GetAll(key)); !slices.Equal(got, want) {
+			t.Errorf("GetAll(%d) = %v, want %v", key, got, want)
+		}
+		if c := tree.Count(key); c != len(want) {
+			t.Errorf("Count(%d) = %d, want %d", key, c, len(want))
+		}
+		v, ok := tree.Get(key)
+		if ok != (len(want) > 0) || (ok && v != want[0]) {
+			t.Errorf("Get(%d) = (%q, %t)", key, v, ok)
+		}
+	}
+
+	all := []keyValue[int, string]{{1, "b"}, {1, "f"}, {2, "a"}, {2, "c"}, {2, "e"}, {3, "d"}}
+	if got := collectKeyValue(tree.All()); !slices.Equal(got, all) {
+		t.Errorf("All() = %v, want %v", got, all)
+	}
+	backward := slices.Clone(all)
+	slices.Reverse(backward)
+	if got := collectKeyValue(tree.Backward()); !slices.Equal(got, backward) {
+		t.Errorf("Backward() = %v, want %v", got, backward)
+	}
+	if got := collectKeyValue(tree.Scan(2, 3)); !slices.Equal(got, all[2:]) {
+		t.Errorf("Scan(2, 3) = %v, want %v", got, all[2:])
+	}
+	if got := collectKeyValue(tree.Scan(2, 1)); !slices.Equal(got, backward[1:]) {
+		t.Errorf("Scan(2, 1) = %v, want %v", got, backward[1:])
+	}
+	if k, v, ok := tree.Min(); !ok || k != 1 || v != "b" {
+		t.Errorf("Min() = (%d, %q, %t)", k, v, ok)
+	}
+	if k, v, ok := tree.Max(); !ok || k != 3 || v != "d" {
+		t.Errorf("Max() = (%d, %q, %t)", k, v, ok)
+	}
+
+	if !tree.RemoveOne(2) {
+		t.Fatal("RemoveOne(2) = false")
+	}
+	if got := slices.Collect(tree.GetAll(2)); !slices.Equal(got, []string{"c", "e"}) {
+		t.Fatalf("GetAll(2) after RemoveOne = %v, want [c e]", got)
+	}
+	if n := tree.RemoveAll(1); n != 2 {
+		t.Fatalf("RemoveAll(1) = %d, want 2", n)
+	}
+	if tree.RemoveOne(1) {
+		t.Fatal("RemoveOne(1) = true after RemoveAll(1)")
+	}
+	// duplicates inserted after removal still come last.
+	tree.InsertDup(2, "g")
+	if got := collectKeyValue(tree.All()); !slices.Equal(got, []keyValue[int, string]{{2, "c"}, {2, "e"}, {2, "g"}, {3, "d"}}) {
+		t.Fatalf("All() = %v", got)
+	}
+	assertValid(t, tree.tree)
+}
@@ -0,0 +1,337 @@
+package btree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// PersistentLLBTree is an immutable variant of [LLBTree].
+//
+// Insert and Remove never modify the receiver; instead they return a new tree
+// which shares every unchanged node with the receiver (path copying).
+// Each update therefore allocates only O(log n) nodes.
+//
+// Since nodes are never mutated once they are reachable from a PersistentLLBTree,
+// it is safe to read a tree, and to iterate over it, from multiple goroutines.
+type PersistentLLBTree[K, V any] struct {
+	cmp  func(l, r K) int
+	root *llbtreeNode[K, V]
+}
+
+func NewPersistentLLBTree[K, V any](cmp func(l, r K) int) *PersistentLLBTree[K, V] {
+	return &PersistentLLBTree[K, V]{
+		cmp: cmp,
+	}
+}
+
+func NewPersistentLLBTreeOrdered[K cmp.Ordered, V any]() *PersistentLLBTree[K, V] {
+	return &PersistentLLBTree[K, V]{
+		cmp: cmp.Compare[K],
+	}
+}
+
+// Insert returns a new tree where key is mapped to value.
+func (t *PersistentLLBTree[K, V]) Insert(key K, value V) *PersistentLLBTree[K, V] {
+	root := t.insert(t.root, key, value)
+	root.red = false
+	return &PersistentLLBTree[K, V]{
+		cmp:  t.cmp,
+		root: root,
+	}
+}
+
+// InsertSeq returns a new tree where all key-value pairs from seq are inserted.
+func (t *PersistentLLBTree[K, V]) InsertSeq(seq iter.Seq2[K, V]) *PersistentLLBTree[K, V] {
+	for k, v := range seq {
+		t = t.Insert(k, v)
+	}
+	return t
+}
+
+// Remove returns a new tree without key.
+// If key is not in t, Remove returns t itself and false.
+func (t *PersistentLLBTree[K, V]) Remove(key K) (*PersistentLLBTree[K, V], bool) {
+	if t.get(key) == nil {
+		return t, false
+	}
+	root := t.root
+	if !root.left.isRed() && !root.right.isRed() {
+		root = root.copy()
+		root.red = true
+	}
+	root = t.delete(root, key)
+	if root != nil {
+		root.red = false
+	}
+	return &PersistentLLBTree[K, V]{
+		cmp:  t.cmp,
+		root: root,
+	}, true
+}
+
+func (t *PersistentLLBTree[K, V]) Get(key K) (value V, ok bool) {
+	n := t.get(key)
+	if n == nil {
+		return
+	}
+	return n.value, true
+}
+
+// Len returns the number of elements in t.
+func (t *PersistentLLBTree[K, V]) Len() int {
+	return t.root.len()
+}
+
+func (t *PersistentLLBTree[K, V]) Min() (key K, value V, ok bool) {
+	n := t.root
+	if n == nil {
+		return
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+func (t *PersistentLLBTree[K, V]) Max() (key K, value V, ok bool) {
+	n := t.root
+	if n == nil {
+		return
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+func (t *PersistentLLBTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		ascend(t.root, yield)
+	}
+}
+
+func (t *PersistentLLBTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		descend(t.root, yield)
+	}
+}
+
+// Scan is like [LLBTree.Scan]:
+// it returns an iterator over key-value pairs between lo and hi, both inclusive.
+// If lo is greater than hi, pairs are yielded in descending order.
+func (t *PersistentLLBTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.cmp(lo, hi) <= 0 {
+			t.ascendRange(t.root, lo, hi, yield)
+		} else {
+			t.descendRange(t.root, lo, hi, yield)
+		}
+	}
+}
+
+func (t *PersistentLLBTree[K, V]) get(key K) *llbtreeNode[K, V] {
+	n := t.root
+	for n != nil {
+		switch c := t.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func (t *PersistentLLBTree[K, V]) insert(h *llbtreeNode[K, V], key K, value V) *llbtreeNode[K, V] {
+	if h == nil {
+		return &llbtreeNode[K, V]{
+			red:   true,
+			size:  1,
+			key:   key,
+			value: value,
+		}
+	}
+	h = h.copy()
+	switch c := t.cmp(key, h.key); {
+	case c < 0:
+		h.left = t.insert(h.left, key, value)
+	case c > 0:
+		h.right = t.insert(h.right, key, value)
+	default:
+		h.value = value
+	}
+	return h.balance()
+}
+
+// delete removes key from the subtree rooted at h.
+// key must be in the subtree.
+func (t *PersistentLLBTree[K, V]) delete(h *llbtreeNode[K, V], key K) *llbtreeNode[K, V] {
+	h = h.copy()
+	if t.cmp(key, h.key) < 0 {
+		if !h.left.isRed() && !h.left.left.isRed() {
+			h = h.moveRedLeftCopy()
+		}
+		h.left = t.delete(h.left, key)
+	} else {
+		if h.left.isRed() {
+			h = h.rotateRightCopy()
+		}
+		if t.cmp(key, h.key) == 0 && h.right == nil {
+			return nil
+		}
+		if !h.right.isRed() && !h.right.left.isRed() {
+			h = h.moveRedRightCopy()
+		}
+		if t.cmp(key, h.key) == 0 {
+			m := h.right
+			for m.left != nil {
+				m = m.left
+			}
+			h.key, h.value = m.key, m.value
+			h.right = h.right.deleteMinCopy()
+		} else {
+			h.right = t.delete(h.right, key)
+		}
+	}
+	return h.balance()
+}
+
+func (t *PersistentLLBTree[K, V]) ascendRange(n *llbtreeNode[K, V], lo, hi K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	cl, ch := t.cmp(n.key, lo), t.cmp(n.key, hi)
+	if cl > 0 && !t.ascendRange(n.left, lo, hi, yield) {
+		return false
+	}
+	if cl >= 0 && ch <= 0 && !yield(n.key, n.value) {
+		return false
+	}
+	if ch < 0 {
+		return t.ascendRange(n.right, lo, hi, yield)
+	}
+	return true
+}
+
+func (t *PersistentLLBTree[K, V]) descendRange(n *llbtreeNode[K, V], hi, lo K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	cl, ch := t.cmp(n.key, lo), t.cmp(n.key, hi)
+	if ch < 0 && !t.descendRange(n.right, hi, lo, yield) {
+		return false
+	}
+	if cl >= 0 && ch <= 0 && !yield(n.key, n.value) {
+		return false
+	}
+	if cl > 0 {
+		return t.descendRange(n.left, hi, lo, yield)
+	}
+	return true
+}
+
+func ascend[K, V any](n *llbtreeNode[K, V], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return ascend(n.left, yield) && yield(n.key, n.value) && ascend(n.right, yield)
+}
+
+func descend[K, V any](n *llbtreeNode[K, V], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return descend(n.right, yield) && yield(n.key, n.value) && descend(n.left, yield)
+}
+
+// Methods suffixed with Copy never mutate nodes reachable from other trees.
+// The receiver must be a node returned from copy in the same operation;
+// other nodes are copied before being modified.
+
+// copy returns a shallow copy of n, detached from its parent.
+// Parent pointers are meaningless for nodes shared among trees.
+func (n *llbtreeNode[K, V]) copy() *llbtreeNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.parent = nil
+	return &c
+}
+
+func (n *llbtreeNode[K, V]) rotateLeftCopy() *llbtreeNode[K, V] {
+	x := n.right.copy()
+	n.right = x.left
+	x.left = n
+	x.red = n.red
+	n.red = true
+	n.updateSize()
+	x.updateSize()
+	return x
+}
+
+func (n *llbtreeNode[K, V]) rotateRightCopy() *llbtreeNode[K, V] {
+	x := n.left.copy()
+	n.left = x.right
+	x.right = n
+	x.red = n.red
+	n.red = true
+	n.updateSize()
+	x.updateSize()
+	return x
+}
+
+func (n *llbtreeNode[K, V]) flipColorCopy() {
+	n.left = n.left.copy()
+	n.right = n.right.copy()
+	n.flipColor()
+}
+
+func (n *llbtreeNode[K, V]) moveRedLeftCopy() *llbtreeNode[K, V] {
+	n.flipColorCopy()
+	if n.right != nil && n.right.left.isRed() {
+		n.right = n.right.rotateRightCopy()
+		n = n.rotateLeftCopy()
+		n.flipColorCopy()
+	}
+	return n
+}
+
+func (n *llbtreeNode[K, V]) moveRedRightCopy() *llbtreeNode[K, V] {
+	n.flipColorCopy()
+	if n.left != nil && n.left.left.isRed() {
+		n = n.rotateRightCopy()
+		n.flipColorCopy()
+	}
+	return n
+}
+
+func (n *llbtreeNode[K, V]) deleteMinCopy() *llbtreeNode[K, V] {
+	n = n.copy()
+	if n.left == nil {
+		return nil
+	}
+	if !n.left.isRed() && !n.left.left.isRed() {
+		n = n.moveRedLeftCopy()
+	}
+	n.left = n.left.deleteMinCopy()
+	return n.balance()
+}
+
+// balance restores the left-leaning red-black invariants at n
+// and recomputes its size.
+func (n *llbtreeNode[K, V]) balance() *llbtreeNode[K, V] {
+	if n.right.isRed() && !n.left.isRed() {
+		n = n.rotateLeftCopy()
+	}
+	if n.left.isRed() && n.left.left.isRed() {
+		n = n.rotateRightCopy()
+	}
+	if n.left.isRed() && n.right.isRed() {
+		n.flipColorCopy()
+	}
+	n.updateSize()
+	return n
+}
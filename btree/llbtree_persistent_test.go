@@ -0,0 +1,183 @@
+package btree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPersistentLLBTree(t *testing.T) {
+	runInAllPattern2(
+		t,
+		llbTreeExpected,
+		func(t *testing.T, pattern []keyValue[int, string]) {
+			var versions []*PersistentLLBTree[int, string]
+			tree := NewPersistentLLBTreeOrdered[int, string]()
+			for _, kv := range pattern {
+				versions = append(versions, tree)
+				tree = tree.Insert(kv.K, kv.V)
+				assertValid(t, tree)
+			}
+			for i, v := range versions {
+				if v.Len() != i {
+					t.Fatalf("older version is modified: expected len = %d, actual = %d", i, v.Len())
+				}
+			}
+
+			collected := collect2(t, func(i int) int { return i }, tree.All())
+			if !slices.Equal(llbTreeExpected, collected) {
+				t.Fatalf("not as expected = %#v", collected)
+			}
+
+			expected := slices.Clone(llbTreeExpected)
+			slices.Reverse(expected)
+			collected = collect2(t, func(i int) int { return i }, tree.Backward())
+			if !slices.Equal(expected, collected) {
+				t.Fatalf("not as expected = %#v", collected)
+			}
+
+			for _, tc := range []struct {
+				lo, hi   int
+				expected []keyValue[int, string]
+			}{
+				{3, 4, []keyValue[int, string]{{4, "baz"}}},
+				{2, 3, []keyValue[int, string]{{2, "bar"}}},
+				{3, 3, nil},
+				{2, 5, []keyValue[int, string]{{2, "bar"}, {4, "baz"}, {5, "qux"}}},
+				{5, 1, []keyValue[int, string]{{5, "qux"}, {4, "baz"}, {2, "bar"}}},
+			} {
+				collected := collect2(t, func(i int) int { return i }, tree.Scan(tc.lo, tc.hi))
+				if !slices.Equal(tc.expected, collected) {
+					t.Errorf("Scan(%d, %d): not equal:\nexpected: %#v\nactual  : %#v", tc.lo, tc.hi, tc.expected, collected)
+				}
+			}
+
+			removed := tree
+			for _, kv := range pattern {
+				var ok bool
+				removed, ok = removed.Remove(kv.K)
+				if !ok {
+					t.Fatalf("tried to Remove %d, but Remove returned false", kv.K)
+				}
+				assertValid(t, removed)
+			}
+			if removed.Len() != 0 {
+				t.Fatalf("must be empty but len = %d", removed.Len())
+			}
+			collected = collect2(t, func(i int) int { return i }, tree.All())
+			if !slices.Equal(llbTreeExpected, collected) {
+				t.Fatalf("older version is modified = %#v", collected)
+			}
+		},
+	)
+}
+
+func TestPersistentLLBTree_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(8, 29))
+	tree := NewPersistentLLBTreeOrdered[int, int]()
+	model := map[int]int{}
+	for range 5000 {
+		k := r.IntN(500)
+		if r.IntN(3) == 0 {
+			var removed bool
+			tree, removed = tree.Remove(k)
+			_, ok := model[k]
+			if removed != ok {
+				t.Fatalf("Remove(%d) = %t, want %t", k, removed, ok)
+			}
+			delete(model, k)
+		} else {
+			tree = tree.Insert(k, k*2)
+			model[k] = k * 2
+		}
+		assertValid(t, tree)
+		if tree.Len() != len(model) {
+			t.Fatalf("wrong len: expected = %d, actual = %d", len(model), tree.Len())
+		}
+	}
+	keys := slices.Sorted(maps.Keys(model))
+	if collected := slices.Collect(omitL(tree.All())); !slices.Equal(keys, collected) {
+		t.Fatalf("not equal:\nexpected: %v\nactual  : %v", keys, collected)
+	}
+	for _, k := range keys {
+		if v, ok := tree.Get(k); !ok || v != model[k] {
+			t.Fatalf("Get(%d) = (%d, %t), want (%d, true)", k, v, ok, model[k])
+		}
+	}
+	if k, _, _ := tree.Min(); k != keys[0] {
+		t.Fatalf("wrong min: %d", k)
+	}
+	if k, _, _ := tree.Max(); k != keys[len(keys)-1] {
+		t.Fatalf("wrong max: %d", k)
+	}
+}
+
+func TestLLBTree_Snapshot(t *testing.T) {
+	tree := NewLLBTreeOrdered[int, string]()
+	tree.InsertSeq(values2(llbTreeExpected))
+
+	snapshot := tree.Snapshot()
+	if tree.root != snapshot.root {
+		t.Fatalf("Snapshot must share nodes")
+	}
+
+	var collected []keyValue[int, string]
+	for k, v := range snapshot.All() {
+		if k == 2 {
+			tree.Insert(3, "corge")
+			tree.Remove(5)
+			tree.Insert(0, "grault")
+		}
+		collected = append(collected, keyValue[int, string]{k, v})
+	}
+	if !slices.Equal(llbTreeExpected, collected) {
+		t.Fatalf("snapshot is modified: %#v", collected)
+	}
+
+	expected := []keyValue[int, string]{
+		{0, "grault"},
+		{2, "bar"},
+		{3, "corge"},
+		{4, "baz"},
+		{7, "quux"},
+	}
+	collected = collect2(t, func(i int) int { return i }, tree.All())
+	if !slices.Equal(expected, collected) {
+		t.Fatalf("not as expected = %#v", collected)
+	}
+	assertValid(t, tree)
+	if k, v, _ := tree.Min(); k != 0 || v != "grault" {
+		t.Fatalf("wrong min: %d, %q", k, v)
+	}
+
+	updated := snapshot.Insert(1, "garply")
+	if snapshot.Len() != len(llbTreeExpected) || updated.Len() != len(llbTreeExpected)+1 {
+		t.Fatalf("wrong len: snapshot = %d, updated = %d", snapshot.Len(), updated.Len())
+	}
+}
+
+func TestLLBTree_Snapshot_while_iteration(t *testing.T) {
+	tree := NewLLBTreeOrdered[int, string]()
+	tree.InsertSeq(values2(llbTreeExpected))
+
+	var collected []keyValue[int, string]
+	for k, v := range tree.All() {
+		if k == 2 {
+			_ = tree.Snapshot()
+			tree.Remove(4)
+			tree.Insert(6, "corge")
+		}
+		collected = append(collected, keyValue[int, string]{k, v})
+	}
+	expected := []keyValue[int, string]{
+		{0, "foo"},
+		{2, "bar"},
+		{5, "qux"},
+		{6, "corge"},
+		{7, "quux"},
+	}
+	if !slices.Equal(expected, collected) {
+		t.Fatalf("not as expected = %#v", collected)
+	}
+}
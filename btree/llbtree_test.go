@@ -3,6 +3,8 @@ package btree
 import (
 	"fmt"
 	"iter"
+	"maps"
+	"math/rand/v2"
 	"slices"
 	"strings"
 	"testing"
@@ -392,3 +394,164 @@ func TestLLBTree_remove_while_iteration(t *testing.T) {
 		},
 	)
 }
+
+func TestLLBTree_order_statistics(t *testing.T) {
+	runInAllPattern2(
+		t,
+		llbTreeExpected,
+		func(t *testing.T, pattern []keyValue[int, string]) {
+			tree := NewLLBTreeOrdered[int, string]()
+			tree.InsertSeq(values2(pattern))
+			assertValid(t, tree)
+
+			if tree.Len() != len(llbTreeExpected) {
+				t.Fatalf("wrong len: expected = %d, actual = %d", len(llbTreeExpected), tree.Len())
+			}
+
+			for i, kv := range llbTreeExpected {
+				k, v, ok := tree.Select(i)
+				if !ok || k != kv.K || v != kv.V {
+					t.Errorf("Select(%d) = (%d, %q, %t), want (%d, %q, true)", i, k, v, ok, kv.K, kv.V)
+				}
+			}
+			for _, i := range []int{-1, len(llbTreeExpected)} {
+				if _, _, ok := tree.Select(i); ok {
+					t.Errorf("Select(%d) must not be ok", i)
+				}
+			}
+
+			for k := -1; k <= 8; k++ {
+				want := 0
+				for _, kv := range llbTreeExpected {
+					if kv.K < k {
+						want++
+					}
+				}
+				if rank := tree.Rank(k); rank != want {
+					t.Errorf("Rank(%d) = %d, want %d", k, rank, want)
+				}
+			}
+
+			for lo := -1; lo <= 8; lo++ {
+				for hi := -1; hi <= 8; hi++ {
+					want := 0
+					for _, kv := range llbTreeExpected {
+						if lo <= kv.K && kv.K < hi {
+							want++
+						}
+					}
+					if count := tree.CountRange(lo, hi); count != want {
+						t.Errorf("CountRange(%d, %d) = %d, want %d", lo, hi, count, want)
+					}
+				}
+			}
+		},
+	)
+}
+
+func TestLLBTree_order_statistics_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 12))
+	tree := NewLLBTreeOrdered[int, int]()
+	model := map[int]bool{}
+	for i := range 5000 {
+		k := r.IntN(500)
+		if r.IntN(3) == 0 {
+			removed := tree.Remove(k)
+			if removed != model[k] {
+				t.Fatalf("Remove(%d) = %t, want %t", k, removed, model[k])
+			}
+			delete(model, k)
+		} else {
+			tree.Insert(k, k)
+			model[k] = true
+		}
+		if tree.Len() != len(model) {
+			t.Fatalf("wrong len: expected = %d, actual = %d", len(model), tree.Len())
+		}
+		if i%100 == 0 {
+			assertValid(t, tree)
+		}
+	}
+	assertValid(t, tree)
+
+	keys := slices.Sorted(maps.Keys(model))
+	for i, k := range keys {
+		if k2, _, ok := tree.Select(i); !ok || k2 != k {
+			t.Fatalf("Select(%d) = %d, want %d", i, k2, k)
+		}
+		if rank := tree.Rank(k); rank != i {
+			t.Fatalf("Rank(%d) = %d, want %d", k, rank, i)
+		}
+	}
+}
+
+func TestLLBTree_Floor_Ceiling_Lower_Higher(t *testing.T) {
+	type result struct {
+		k  int
+		v  string
+		ok bool
+	}
+	runInAllPattern2(
+		t,
+		llbTreeExpected,
+		func(t *testing.T, pattern []keyValue[int, string]) {
+			tree := NewLLBTreeOrdered[int, string]()
+			tree.InsertSeq(values2(pattern))
+
+			for _, tc := range []struct {
+				key                           int
+				floor, ceiling, lower, higher result
+			}{
+				{-1, result{}, result{0, "foo", true}, result{}, result{0, "foo", true}},
+				{0, result{0, "foo", true}, result{0, "foo", true}, result{}, result{2, "bar", true}},
+				{1, result{0, "foo", true}, result{2, "bar", true}, result{0, "foo", true}, result{2, "bar", true}},
+				{4, result{4, "baz", true}, result{4, "baz", true}, result{2, "bar", true}, result{5, "qux", true}},
+				{6, result{5, "qux", true}, result{7, "quux", true}, result{5, "qux", true}, result{7, "quux", true}},
+				{7, result{7, "quux", true}, result{7, "quux", true}, result{5, "qux", true}, result{}},
+				{8, result{7, "quux", true}, result{}, result{7, "quux", true}, result{}},
+			} {
+				for _, m := range []struct {
+					name     string
+					fn       func(key int) (int, string, bool)
+					expected result
+				}{
+					{"Floor", tree.Floor, tc.floor},
+					{"Ceiling", tree.Ceiling, tc.ceiling},
+					{"Lower", tree.Lower, tc.lower},
+					{"Higher", tree.Higher, tc.higher},
+				} {
+					k, v, ok := m.fn(tc.key)
+					if actual := (result{k, v, ok}); actual != m.expected {
+						t.Errorf("%s(%d) = %#v, want %#v", m.name, tc.key, actual, m.expected)
+					}
+				}
+			}
+		},
+	)
+
+	tree := NewLLBTreeOrdered[int, string]()
+	for _, fn := range []func(key int) (int, string, bool){tree.Floor, tree.Ceiling, tree.Lower, tree.Higher} {
+		if _, _, ok := fn(0); ok {
+			t.Errorf("must not be ok for empty tree")
+		}
+	}
+}
+
+func enumerate[V any](seq iter.Seq[V]) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		var i int
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+func assertValid(t *testing.T, v interface{ Validate() error }) {
+	t.Helper()
+	if err := v.Validate(); err != nil {
+		t.Fatalf("invalid: %v", err)
+	}
+}
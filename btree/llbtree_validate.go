@@ -0,0 +1,107 @@
+package btree
+
+import (
+	"fmt"
+)
+
+// Validate checks the internal invariants of t and reports the first violation found.
+// It returns nil if t is valid.
+//
+// Validate checks:
+//
+//   - the root is black,
+//   - no red node has a red child,
+//   - no node has a red right child (red links lean left),
+//   - every path from the root to nil has the same number of black nodes,
+//   - keys are in strictly ascending order in in-order traversal,
+//   - children point back to their parent,
+//   - cached subtree sizes and cached min / max are up to date.
+//
+// Validate visits every node, thus it takes O(n) time.
+func (t *LLBTree[K, V]) Validate() error {
+	if t.root != nil && t.root.parent != nil {
+		return fmt.Errorf("root has parent")
+	}
+	if err := validateLLRB(t.cmp, t.root, true); err != nil {
+		return err
+	}
+	var min, max *llbtreeNode[K, V]
+	if t.root != nil {
+		min, max = *t.root.leftmost(&t.root), *t.root.rightmost(&t.root)
+	}
+	if t.min != min {
+		return fmt.Errorf("stale min: cached = %s, actual = %s", describeNode(t.min), describeNode(min))
+	}
+	if t.max != max {
+		return fmt.Errorf("stale max: cached = %s, actual = %s", describeNode(t.max), describeNode(max))
+	}
+	return nil
+}
+
+// Validate is like [LLBTree.Validate] except that it does not check parent links and cached min / max,
+// which PersistentLLBTree does not maintain.
+func (t *PersistentLLBTree[K, V]) Validate() error {
+	return validateLLRB(t.cmp, t.root, false)
+}
+
+func validateLLRB[K, V any](cmp func(l, r K) int, root *llbtreeNode[K, V], checkParent bool) error {
+	if root.isRed() {
+		return fmt.Errorf("red root %s", describeNode(root))
+	}
+	var prev *llbtreeNode[K, V]
+	var walk func(n *llbtreeNode[K, V]) (blackHeight int, err error)
+	walk = func(n *llbtreeNode[K, V]) (int, error) {
+		if n == nil {
+			return 0, nil
+		}
+		if n.deleted {
+			return 0, fmt.Errorf("deleted node %s is in the tree", describeNode(n))
+		}
+		if n.isRed() && (n.left.isRed() || n.right.isRed()) {
+			return 0, fmt.Errorf("red-red link at %s", describeNode(n))
+		}
+		if n.right.isRed() {
+			return 0, fmt.Errorf("right leaning red link at %s", describeNode(n))
+		}
+		if checkParent {
+			for _, c := range [...]*llbtreeNode[K, V]{n.left, n.right} {
+				if c != nil && c.parent != n {
+					return 0, fmt.Errorf("broken parent link: parent of %s is not %s", describeNode(c), describeNode(n))
+				}
+			}
+		}
+
+		lh, err := walk(n.left)
+		if err != nil {
+			return 0, err
+		}
+		if prev != nil && cmp(prev.key, n.key) >= 0 {
+			return 0, fmt.Errorf("keys out of order: %s is followed by %s", describeNode(prev), describeNode(n))
+		}
+		prev = n
+		rh, err := walk(n.right)
+		if err != nil {
+			return 0, err
+		}
+
+		if lh != rh {
+			return 0, fmt.Errorf("black height mismatch at %s: left = %d, right = %d", describeNode(n), lh, rh)
+		}
+		if size := 1 + n.left.len() + n.right.len(); n.size != size {
+			return 0, fmt.Errorf("stale size at %s: cached = %d, actual = %d", describeNode(n), n.size, size)
+		}
+		if n.isBlack() {
+			lh++
+		}
+		return lh, nil
+	}
+	_, err := walk(root)
+	return err
+}
+
+func describeNode[K, V any](n *llbtreeNode[K, V]) string {
+	if n == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("node(key = %v)", n.key)
+}
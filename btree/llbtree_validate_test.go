@@ -0,0 +1,59 @@
+package btree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLLBTree_Validate(t *testing.T) {
+	newTree := func() *LLBTree[int, int] {
+		tree := NewLLBTreeOrdered[int, int]()
+		for i := range 20 {
+			tree.Insert(i, i)
+		}
+		assertValid(t, tree)
+		return tree
+	}
+	findRed := func(tree *LLBTree[int, int], withChild bool) *llbtreeNode[int, int] {
+		var found *llbtreeNode[int, int]
+		var walk func(n *llbtreeNode[int, int])
+		walk = func(n *llbtreeNode[int, int]) {
+			if n == nil || found != nil {
+				return
+			}
+			if n.red && (!withChild || n.left != nil) {
+				found = n
+				return
+			}
+			walk(n.left)
+			walk(n.right)
+		}
+		walk(tree.root)
+		return found
+	}
+
+	for _, tc := range []struct {
+		name    string
+		corrupt func(tree *LLBTree[int, int])
+		msg     string
+	}{
+		{"red root", func(tree *LLBTree[int, int]) { tree.root.red = true }, "red root"},
+		{"red-red", func(tree *LLBTree[int, int]) { findRed(tree, true).left.red = true }, "red-red"},
+		{"right leaning", func(tree *LLBTree[int, int]) { tree.max.red = true }, "right leaning"},
+		{"black height", func(tree *LLBTree[int, int]) { findRed(tree, false).red = false }, "black height"},
+		{"parent", func(tree *LLBTree[int, int]) { tree.root.left.parent = tree.root.right }, "parent"},
+		{"order", func(tree *LLBTree[int, int]) { tree.root.left.key = 100 }, "out of order"},
+		{"size", func(tree *LLBTree[int, int]) { tree.root.size++ }, "stale size"},
+		{"min", func(tree *LLBTree[int, int]) { tree.min = tree.root }, "stale min"},
+		{"max", func(tree *LLBTree[int, int]) { tree.max = nil }, "stale max"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := newTree()
+			tc.corrupt(tree)
+			err := tree.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.msg) {
+				t.Fatalf("must report %q but is %v", tc.msg, err)
+			}
+		})
+	}
+}
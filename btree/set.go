@@ -0,0 +1,184 @@
+package btree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Union returns a new tree containing every key in a or b.
+// For keys in both trees, merge is called to determine the value.
+// If merge is nil, values from a win.
+//
+// a and b must be ordered by the same comparator; the returned tree uses the one of a,
+// as well as its options (see [NewLLBTree]) and codecs (see [LLBTree.SetCodec]).
+// Union walks both trees once and builds the result by [LLBTree.BuildSorted],
+// thus it takes O(n+m) time.
+func Union[K, V any](a, b *LLBTree[K, V], merge func(key K, va, vb V) V) *LLBTree[K, V] {
+	return combine(a, b, true, true, true, merge)
+}
+
+// Intersect returns a new tree containing keys in both a and b.
+// merge is called to determine the value. If merge is nil, values from a win.
+//
+// See [Union] for requirements and complexity.
+func Intersect[K, V any](a, b *LLBTree[K, V], merge func(key K, va, vb V) V) *LLBTree[K, V] {
+	return combine(a, b, false, true, false, merge)
+}
+
+// Difference returns a new tree containing key-value pairs of a whose keys are not in b.
+//
+// See [Union] for requirements and complexity.
+func Difference[K, V any](a, b *LLBTree[K, V]) *LLBTree[K, V] {
+	return combine(a, b, true, false, false, nil)
+}
+
+// SymmetricDifference returns a new tree containing key-value pairs whose keys are in either a or b but not in both.
+//
+// See [Union] for requirements and complexity.
+func SymmetricDifference[K, V any](a, b *LLBTree[K, V]) *LLBTree[K, V] {
+	return combine(a, b, true, false, true, nil)
+}
+
+// combine merges a and b by linearly walking them in order.
+// onlyA, both and onlyB tell whether keys found only in a, both in a and b, only in b should be kept.
+func combine[K, V any](
+	a, b *LLBTree[K, V],
+	onlyA, both, onlyB bool,
+	merge func(key K, va, vb V) V,
+) *LLBTree[K, V] {
+	var items []btreeItem[K, V]
+	na, nb := a.min, b.min
+	for na != nil || nb != nil {
+		var c int
+		switch {
+		case na == nil:
+			c = 1
+		case nb == nil:
+			c = -1
+		default:
+			c = a.cmp(na.key, nb.key)
+		}
+		switch {
+		case c < 0:
+			if onlyA {
+				items = append(items, btreeItem[K, V]{na.key, na.value})
+			}
+			na = na.next(&a.root)
+		case c > 0:
+			if onlyB {
+				items = append(items, btreeItem[K, V]{nb.key, nb.value})
+			}
+			nb = nb.next(&b.root)
+		default:
+			if both {
+				v := na.value
+				if merge != nil {
+					v = merge(na.key, na.value, nb.value)
+				}
+				items = append(items, btreeItem[K, V]{na.key, v})
+			}
+			na, nb = na.next(&a.root), nb.next(&b.root)
+		}
+	}
+	out := a.adopt(nil)
+	out.buildSorted(items)
+	return out
+}
+
+// Set is a sorted set backed by [LLBTree].
+type Set[K any] struct {
+	tree *LLBTree[K, struct{}]
+}
+
+func NewSet[K any](cmp func(l, r K) int) *Set[K] {
+	return &Set[K]{tree: NewLLBTree[K, struct{}](cmp)}
+}
+
+func NewSetOrdered[K cmp.Ordered]() *Set[K] {
+	return &Set[K]{tree: NewLLBTreeOrdered[K, struct{}]()}
+}
+
+// Tree returns the underlying tree.
+func (s *Set[K]) Tree() *LLBTree[K, struct{}] {
+	return s.tree
+}
+
+// Add adds key to s and reports whether key was newly added.
+func (s *Set[K]) Add(key K) (added bool) {
+	if s.tree.get(key) != nil {
+		return false
+	}
+	s.tree.Insert(key, struct{}{})
+	return true
+}
+
+func (s *Set[K]) AddSeq(seq iter.Seq[K]) {
+	for k := range seq {
+		s.tree.Insert(k, struct{}{})
+	}
+}
+
+func (s *Set[K]) Has(key K) bool {
+	return s.tree.get(key) != nil
+}
+
+func (s *Set[K]) Remove(key K) (removed bool) {
+	return s.tree.Remove(key)
+}
+
+func (s *Set[K]) Len() int {
+	return s.tree.Len()
+}
+
+func (s *Set[K]) Min() (key K, ok bool) {
+	key, _, ok = s.tree.Min()
+	return
+}
+
+func (s *Set[K]) Max() (key K, ok bool) {
+	key, _, ok = s.tree.Max()
+	return
+}
+
+func (s *Set[K]) All() iter.Seq[K] {
+	return keys(s.tree.All())
+}
+
+func (s *Set[K]) Backward() iter.Seq[K] {
+	return keys(s.tree.Backward())
+}
+
+// Scan is like [LLBTree.Scan] but yields only keys.
+func (s *Set[K]) Scan(lo, hi K) iter.Seq[K] {
+	return keys(s.tree.Scan(lo, hi))
+}
+
+// Union returns a new set containing keys in either s or other.
+func (s *Set[K]) Union(other *Set[K]) *Set[K] {
+	return &Set[K]{tree: Union(s.tree, other.tree, nil)}
+}
+
+// Intersect returns a new set containing keys in both s and other.
+func (s *Set[K]) Intersect(other *Set[K]) *Set[K] {
+	return &Set[K]{tree: Intersect(s.tree, other.tree, nil)}
+}
+
+// Difference returns a new set containing keys in s but not in other.
+func (s *Set[K]) Difference(other *Set[K]) *Set[K] {
+	return &Set[K]{tree: Difference(s.tree, other.tree)}
+}
+
+// SymmetricDifference returns a new set containing keys in either s or other but not in both.
+func (s *Set[K]) SymmetricDifference(other *Set[K]) *Set[K] {
+	return &Set[K]{tree: SymmetricDifference(s.tree, other.tree)}
+}
+
+func keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package btree
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestUnion_Intersect_Difference_SymmetricDifference(t *testing.T) {
+	a, b := NewLLBTreeOrdered[int, string](SetSlabSize(4), SetNodeReuse(true)), NewLLBTreeOrdered[int, string]()
+	a.SetCodec(IntCodec[int]{}, StringCodec[string]{})
+	a.InsertSeq(values2([]keyValue[int, string]{{1, "a1"}, {2, "a2"}, {4, "a4"}, {6, "a6"}}))
+	b.InsertSeq(values2([]keyValue[int, string]{{2, "b2"}, {3, "b3"}, {6, "b6"}, {7, "b7"}}))
+
+	concat := func(key int, va, vb string) string { return va + vb }
+
+	for _, tc := range []struct {
+		name     string
+		result   *LLBTree[int, string]
+		expected []keyValue[int, string]
+	}{
+		{
+			"Union",
+			Union(a, b, concat),
+			[]keyValue[int, string]{{1, "a1"}, {2, "a2b2"}, {3, "b3"}, {4, "a4"}, {6, "a6b6"}, {7, "b7"}},
+		},
+		{
+			"Union_nil_merge",
+			Union(a, b, nil),
+			[]keyValue[int, string]{{1, "a1"}, {2, "a2"}, {3, "b3"}, {4, "a4"}, {6, "a6"}, {7, "b7"}},
+		},
+		{
+			"Intersect",
+			Intersect(a, b, concat),
+			[]keyValue[int, string]{{2, "a2b2"}, {6, "a6b6"}},
+		},
+		{
+			"Difference",
+			Difference(a, b),
+			[]keyValue[int, string]{{1, "a1"}, {4, "a4"}},
+		},
+		{
+			"SymmetricDifference",
+			SymmetricDifference(a, b),
+			[]keyValue[int, string]{{1, "a1"}, {3, "b3"}, {4, "a4"}, {7, "b7"}},
+		},
+		{
+			"Union_empty",
+			Union(a, NewLLBTreeOrdered[int, string](), nil),
+			[]keyValue[int, string]{{1, "a1"}, {2, "a2"}, {4, "a4"}, {6, "a6"}},
+		},
+		{
+			"Intersect_empty",
+			Intersect(NewLLBTreeOrdered[int, string](), b, nil),
+			nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assertValid(t, tc.result)
+			collected := collect2(t, func(i int) int { return i }, tc.result.All())
+			if !slices.Equal(tc.expected, collected) {
+				t.Fatalf("not equal:\nexpected: %#v\nactual  : %#v", tc.expected, collected)
+			}
+		})
+	}
+
+	if a.Len() != 4 || b.Len() != 4 {
+		t.Fatalf("inputs are modified")
+	}
+
+	// results inherit options and codecs of a.
+	for _, result := range []*LLBTree[int, string]{Union(a, b, nil), Intersect(a, b, nil), Difference(a, b), SymmetricDifference(a, b)} {
+		if result.alloc.param != a.alloc.param {
+			t.Errorf("options not inherited: %+v", result.alloc.param)
+		}
+		if result.keyCodec != a.keyCodec || result.valueCodec != a.valueCodec {
+			t.Errorf("codecs not inherited: %T, %T", result.keyCodec, result.valueCodec)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(10, 11))
+	a, b := NewSetOrdered[int](), NewSetOrdered[int]()
+	ma, mb := map[int]bool{}, map[int]bool{}
+	for range 300 {
+		k := r.IntN(200)
+		if a.Add(k) == ma[k] {
+			t.Fatalf("Add(%d) reported wrong result", k)
+		}
+		ma[k] = true
+		k = r.IntN(200)
+		b.Add(k)
+		mb[k] = true
+	}
+
+	filter := func(pred func(k int) bool) []int {
+		var out []int
+		for k := range 200 {
+			if pred(k) {
+				out = append(out, k)
+			}
+		}
+		return out
+	}
+
+	for _, tc := range []struct {
+		name     string
+		result   *Set[int]
+		expected []int
+	}{
+		{"Union", a.Union(b), filter(func(k int) bool { return ma[k] || mb[k] })},
+		{"Intersect", a.Intersect(b), filter(func(k int) bool { return ma[k] && mb[k] })},
+		{"Difference", a.Difference(b), filter(func(k int) bool { return ma[k] && !mb[k] })},
+		{"SymmetricDifference", a.SymmetricDifference(b), filter(func(k int) bool { return ma[k] != mb[k] })},
+	} {
+		if collected := slices.Collect(tc.result.All()); !slices.Equal(tc.expected, collected) {
+			t.Errorf("%s: not equal:\nexpected: %v\nactual  : %v", tc.name, tc.expected, collected)
+		}
+		if tc.result.Len() != len(tc.expected) {
+			t.Errorf("%s: wrong len: %d", tc.name, tc.result.Len())
+		}
+	}
+
+	for k := range 200 {
+		if a.Has(k) != ma[k] {
+			t.Fatalf("Has(%d) must be %t", k, ma[k])
+		}
+	}
+	expected := filter(func(k int) bool { return ma[k] })
+	if k, ok := a.Min(); !ok || k != expected[0] {
+		t.Fatalf("wrong min: %d", k)
+	}
+	if k, ok := a.Max(); !ok || k != expected[len(expected)-1] {
+		t.Fatalf("wrong max: %d", k)
+	}
+	if !a.Remove(expected[0]) || a.Has(expected[0]) || a.Remove(expected[0]) {
+		t.Fatalf("wrong Remove")
+	}
+}
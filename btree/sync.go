@@ -0,0 +1,163 @@
+package btree
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+)
+
+// SyncLLBTree is [LLBTree] guarded by [sync.RWMutex].
+// It is safe for concurrent use by multiple goroutines.
+//
+// Iterators returned from All, Backward and Scan hold the read lock during the entire iteration.
+// The loop body must not call any method of the same tree; otherwise it may deadlock.
+// To mutate the tree while iterating, iterate over a snapshot taken by [SyncLLBTree.Snapshot] instead,
+// which does not hold any lock.
+type SyncLLBTree[K, V any] struct {
+	mu   sync.RWMutex
+	tree *LLBTree[K, V]
+}
+
+func NewSyncLLBTree[K, V any](cmp func(l, r K) int) *SyncLLBTree[K, V] {
+	return &SyncLLBTree[K, V]{tree: NewLLBTree[K, V](cmp)}
+}
+
+func NewSyncLLBTreeOrdered[K cmp.Ordered, V any]() *SyncLLBTree[K, V] {
+	return &SyncLLBTree[K, V]{tree: NewLLBTreeOrdered[K, V]()}
+}
+
+func (t *SyncLLBTree[K, V]) Insert(key K, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.Insert(key, value)
+}
+
+func (t *SyncLLBTree[K, V]) Get(key K) (value V, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Get(key)
+}
+
+func (t *SyncLLBTree[K, V]) Remove(key K) (removed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Remove(key)
+}
+
+func (t *SyncLLBTree[K, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Len()
+}
+
+func (t *SyncLLBTree[K, V]) Min() (key K, value V, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Min()
+}
+
+func (t *SyncLLBTree[K, V]) Max() (key K, value V, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Max()
+}
+
+// GetOrInsert returns the existing value for key if present.
+// Otherwise, it inserts value and returns it.
+// loaded is true if the value was already present.
+func (t *SyncLLBTree[K, V]) GetOrInsert(key K, value V) (actual V, loaded bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n := t.tree.get(key); n != nil {
+		return n.value, true
+	}
+	t.tree.Insert(key, value)
+	return value, false
+}
+
+// Update atomically updates the value for key.
+//
+// fn is called with the current value and whether key is present,
+// and returns the new value and whether key should be kept.
+// If keep is false, key is removed from the tree.
+// Update returns what fn returned.
+//
+// fn is called while the write lock is held; it must not call any method of t.
+func (t *SyncLLBTree[K, V]) Update(key K, fn func(old V, loaded bool) (newValue V, keep bool)) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var (
+		old    V
+		loaded bool
+	)
+	if n := t.tree.get(key); n != nil {
+		old, loaded = n.value, true
+	}
+	newValue, keep := fn(old, loaded)
+	if keep {
+		t.tree.Insert(key, newValue)
+	} else if loaded {
+		t.tree.Remove(key)
+	}
+	return newValue, keep
+}
+
+// CompareAndSwap swaps the old and new values for key
+// if the value stored in the tree is equal to old.
+// The old value must be of a comparable type, as is the case for [sync.Map.CompareAndSwap].
+func (t *SyncLLBTree[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := t.tree.get(key)
+	if n == nil || any(n.value) != any(old) {
+		return false
+	}
+	t.tree.Insert(key, new)
+	return true
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old.
+// The old value must be of a comparable type.
+func (t *SyncLLBTree[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := t.tree.get(key)
+	if n == nil || any(n.value) != any(old) {
+		return false
+	}
+	return t.tree.Remove(key)
+}
+
+// Snapshot returns an immutable view of t's current content.
+// The first mutation on t after Snapshot copies the whole tree; see [LLBTree.Snapshot].
+func (t *SyncLLBTree[K, V]) Snapshot() *PersistentLLBTree[K, V] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Snapshot()
+}
+
+// All returns an iterator over all key-value pairs in ascending order.
+// The read lock is held until the iteration ends.
+func (t *SyncLLBTree[K, V]) All() iter.Seq2[K, V] {
+	return t.locked(t.tree.All())
+}
+
+// Backward returns an iterator over all key-value pairs in descending order.
+// The read lock is held until the iteration ends.
+func (t *SyncLLBTree[K, V]) Backward() iter.Seq2[K, V] {
+	return t.locked(t.tree.Backward())
+}
+
+// Scan is like [LLBTree.Scan].
+// The read lock is held until the iteration ends.
+func (t *SyncLLBTree[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
+	return t.locked(t.tree.Scan(lo, hi))
+}
+
+func (t *SyncLLBTree[K, V]) locked(seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+		seq(yield)
+	}
+}
@@ -0,0 +1,117 @@
+package btree
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSyncLLBTree(t *testing.T) {
+	tree := NewSyncLLBTreeOrdered[int, int]()
+
+	if v, loaded := tree.GetOrInsert(1, 10); loaded || v != 10 {
+		t.Fatalf("GetOrInsert = (%d, %t), want (10, false)", v, loaded)
+	}
+	if v, loaded := tree.GetOrInsert(1, 20); !loaded || v != 10 {
+		t.Fatalf("GetOrInsert = (%d, %t), want (10, true)", v, loaded)
+	}
+
+	if tree.CompareAndSwap(1, 20, 30) {
+		t.Fatalf("CompareAndSwap must fail")
+	}
+	if !tree.CompareAndSwap(1, 10, 30) {
+		t.Fatalf("CompareAndSwap must succeed")
+	}
+	if v, _ := tree.Get(1); v != 30 {
+		t.Fatalf("wrong value: %d", v)
+	}
+	if tree.CompareAndSwap(2, 0, 1) {
+		t.Fatalf("CompareAndSwap must fail for missing key")
+	}
+
+	if tree.CompareAndDelete(1, 10) {
+		t.Fatalf("CompareAndDelete must fail")
+	}
+	if !tree.CompareAndDelete(1, 30) {
+		t.Fatalf("CompareAndDelete must succeed")
+	}
+	if _, ok := tree.Get(1); ok {
+		t.Fatalf("must be deleted")
+	}
+
+	tree.Update(3, func(old int, loaded bool) (int, bool) {
+		if loaded {
+			t.Fatalf("must not be loaded")
+		}
+		return 5, true
+	})
+	tree.Update(3, func(old int, loaded bool) (int, bool) {
+		if !loaded || old != 5 {
+			t.Fatalf("wrong old: (%d, %t)", old, loaded)
+		}
+		return 0, false
+	})
+	if tree.Len() != 0 {
+		t.Fatalf("must be empty")
+	}
+}
+
+func TestSyncLLBTree_concurrent(t *testing.T) {
+	tree := NewSyncLLBTreeOrdered[int, int]()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				tree.Update(j%10, func(old int, _ bool) (int, bool) { return old + 1, true })
+				tree.Insert(100+i*1000+j, j)
+				if j%3 == 0 {
+					tree.Remove(100 + i*1000 + j)
+				}
+				for range tree.Scan(0, 9) {
+				}
+			}
+		}()
+	}
+
+	// iterate over snapshots concurrently with writers.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			snapshot := tree.Snapshot()
+			var prev int
+			for k := range snapshot.All() {
+				if k < prev {
+					t.Errorf("not sorted")
+				}
+				prev = k
+			}
+		}
+	}()
+
+	wg.Wait()
+	<-done
+
+	for k, v := range tree.Scan(0, 9) {
+		if v != 8*20 {
+			t.Fatalf("lost update at %d: %d", k, v)
+		}
+	}
+	if tree.Len() != 10+8*(200-67) {
+		t.Fatalf("wrong len: %d", tree.Len())
+	}
+
+	snapshot := tree.Snapshot()
+	for k := range snapshot.All() {
+		tree.Remove(k)
+	}
+	if tree.Len() != 0 || snapshot.Len() != 10+8*(200-67) {
+		t.Fatalf("wrong len: tree = %d, snapshot = %d", tree.Len(), snapshot.Len())
+	}
+	if collected := slices.Collect(omitL(tree.All())); len(collected) != 0 {
+		t.Fatalf("must be empty: %v", collected)
+	}
+}
@@ -0,0 +1,181 @@
+package btree
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// TTLMap is a map whose entries expire after their time-to-live elapses.
+//
+// TTLMap indexes entries both by key, with a built-in map,
+// and by deadline, with an [LLBTree], so that Get, Set and Delete take O(log n) time at most
+// and Expire can pop expired entries in deadline order without scanning the whole map.
+//
+// Expired entries are not removed automatically.
+// Get hides them, but they stay in the map until popped by Expire or Sweep.
+//
+// TTLMap is safe for concurrent use.
+type TTLMap[K comparable, V any] struct {
+	mu        sync.Mutex
+	clock     clockwork.Clock
+	seq       uint64
+	entries   map[K]*ttlEntry[V]
+	deadlines *LLBTree[ttlKey, K]
+}
+
+// ttlKey orders entries by deadline, then by insertion order.
+type ttlKey struct {
+	deadline time.Time
+	seq      uint64
+}
+
+type ttlEntry[V any] struct {
+	key   ttlKey
+	value V
+}
+
+// NewTTLMap returns a new empty TTLMap.
+// clock is used to get the current time. If clock is nil, the real clock is used.
+func NewTTLMap[K comparable, V any](clock clockwork.Clock) *TTLMap[K, V] {
+	if clock == nil {
+		clock = clockwork.NewRealClock()
+	}
+	return &TTLMap[K, V]{
+		clock:   clock,
+		entries: make(map[K]*ttlEntry[V]),
+		deadlines: NewLLBTree[ttlKey, K](func(l, r ttlKey) int {
+			if c := l.deadline.Compare(r.deadline); c != 0 {
+				return c
+			}
+			switch {
+			case l.seq < r.seq:
+				return -1
+			case l.seq > r.seq:
+				return 1
+			}
+			return 0
+		}),
+	}
+}
+
+// Set maps key to value, which expires after ttl elapses.
+// Set replaces the value and the deadline of the existing entry for key.
+// If ttl is not positive, the entry is already expired when Set returns.
+func (m *TTLMap[K, V]) Set(key K, value V, ttl time.Duration) {
+	deadline := m.clock.Now().Add(ttl)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.deadlines.Remove(e.key)
+	}
+	m.seq++
+	k := ttlKey{deadline: deadline, seq: m.seq}
+	m.entries[key] = &ttlEntry[V]{key: k, value: value}
+	m.deadlines.Insert(k, key)
+}
+
+// Get returns the value for key if key exists and it is not expired.
+func (m *TTLMap[K, V]) Get(key K) (value V, ok bool) {
+	now := m.clock.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || !now.Before(e.key.deadline) {
+		return value, false
+	}
+	return e.value, true
+}
+
+// Deadline returns the time at which the entry for key expires.
+func (m *TTLMap[K, V]) Deadline(key K) (deadline time.Time, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return
+	}
+	return e.key.deadline, true
+}
+
+// Delete removes the entry for key, regardless of whether it is expired.
+func (m *TTLMap[K, V]) Delete(key K) (deleted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+	m.deadlines.Remove(e.key)
+	return true
+}
+
+// Len returns the number of entries in m, including expired entries not yet popped.
+func (m *TTLMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Expire returns an iterator which pops entries whose deadline is at or before now,
+// in ascending order of deadline.
+//
+// Each entry is removed from m right before it is yielded.
+// Entries are popped one by one and the lock is not held while yielding,
+// so the loop body may call methods of m.
+// Breaking out of the loop leaves remaining expired entries in m.
+func (m *TTLMap[K, V]) Expire(now time.Time) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for {
+			key, value, ok := m.pop(now)
+			if !ok || !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+func (m *TTLMap[K, V]) pop(now time.Time) (key K, value V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, key, ok := m.deadlines.Min()
+	if !ok || k.deadline.After(now) {
+		return key, value, false
+	}
+	m.deadlines.Remove(k)
+	e := m.entries[key]
+	delete(m.entries, key)
+	return key, e.value, true
+}
+
+// Sweep pops expired entries every interval, calling onExpire for each of them if onExpire is non-nil,
+// until ctx is cancelled.
+// Sweep blocks; run it in its own goroutine.
+// It uses the clock passed to [NewTTLMap], thus a fake clock can drive it in tests.
+func (m *TTLMap[K, V]) Sweep(ctx context.Context, interval time.Duration, onExpire func(key K, value V)) {
+	ticker := m.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.Chan():
+			for k, v := range m.Expire(now) {
+				if onExpire != nil {
+					onExpire(k, v)
+				}
+			}
+		}
+	}
+}
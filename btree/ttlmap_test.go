@@ -0,0 +1,123 @@
+package btree
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+func TestTTLMap(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	m := NewTTLMap[string, int](clock)
+
+	m.Set("foo", 1, 3*time.Second)
+	m.Set("bar", 2, time.Second)
+	m.Set("baz", 3, 2*time.Second)
+	m.Set("qux", 4, 2*time.Second)
+	m.Set("foo", 5, 4*time.Second)
+
+	if v, ok := m.Get("foo"); !ok || v != 5 {
+		t.Fatalf("Get(foo) = (%d, %t), want (5, true)", v, ok)
+	}
+	if d, ok := m.Deadline("foo"); !ok || !d.Equal(clock.Now().Add(4*time.Second)) {
+		t.Fatalf("Deadline(foo) = (%v, %t)", d, ok)
+	}
+	if l := m.Len(); l != 4 {
+		t.Fatalf("Len() = %d, want 4", l)
+	}
+
+	clock.Advance(time.Second)
+	if _, ok := m.Get("bar"); ok {
+		t.Fatal("Get(bar) should not return expired entry")
+	}
+	if l := m.Len(); l != 4 {
+		t.Fatalf("Len() = %d, want 4 since expired entries are not yet popped", l)
+	}
+
+	clock.Advance(time.Second)
+	// entries with same deadline are popped in insertion order.
+	if got := collectKeyValue(m.Expire(clock.Now())); !slices.Equal(got, []keyValue[string, int]{{"bar", 2}, {"baz", 3}, {"qux", 4}}) {
+		t.Fatalf("Expire() = %v", got)
+	}
+	if got := collectKeyValue(m.Expire(clock.Now())); len(got) != 0 {
+		t.Fatalf("Expire() after popping = %v, want empty", got)
+	}
+	if l := m.Len(); l != 1 {
+		t.Fatalf("Len() = %d, want 1", l)
+	}
+
+	if !m.Delete("foo") {
+		t.Fatal("Delete(foo) = false")
+	}
+	if m.Delete("foo") {
+		t.Fatal("Delete(foo) = true for deleted key")
+	}
+	clock.Advance(time.Hour)
+	if got := collectKeyValue(m.Expire(clock.Now())); len(got) != 0 {
+		t.Fatalf("Expire() after Delete = %v, want empty", got)
+	}
+}
+
+func TestTTLMap_Expire_break(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	m := NewTTLMap[int, int](clock)
+	for i := range 10 {
+		m.Set(i, i, time.Duration(i)*time.Second)
+	}
+	clock.Advance(5 * time.Second)
+
+	var popped []int
+	for k := range m.Expire(clock.Now()) {
+		popped = append(popped, k)
+		// the lock is not held while yielding.
+		m.Set(100+k, k, time.Hour)
+		if k == 2 {
+			break
+		}
+	}
+	if !slices.Equal(popped, []int{0, 1, 2}) {
+		t.Fatalf("popped = %v, want [0 1 2]", popped)
+	}
+	if got := slices.Collect(keys(m.Expire(clock.Now()))); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Fatalf("Expire() = %v, want [3 4 5]", got)
+	}
+	if l := m.Len(); l != 7 {
+		t.Fatalf("Len() = %d, want 7", l)
+	}
+}
+
+func TestTTLMap_Sweep(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	m := NewTTLMap[string, int](clock)
+	m.Set("foo", 1, 500*time.Millisecond)
+	m.Set("bar", 2, 1500*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	expired := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Sweep(ctx, time.Second, func(key string, value int) {
+			expired <- key
+		})
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if k := <-expired; k != "foo" {
+		t.Fatalf("expired %q, want foo", k)
+	}
+	clock.Advance(time.Second)
+	if k := <-expired; k != "bar" {
+		t.Fatalf("expired %q, want bar", k)
+	}
+
+	cancel()
+	<-done
+	if l := m.Len(); l != 0 {
+		t.Fatalf("Len() = %d, want 0", l)
+	}
+}
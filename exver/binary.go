@@ -0,0 +1,138 @@
+package exver
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Core{}
+	_ encoding.BinaryAppender    = Core{}
+	_ encoding.BinaryUnmarshaler = (*Core)(nil)
+)
+
+const coreBinaryLen = 8
+
+// AppendBinary implements [encoding.BinaryAppender].
+//
+// c is encoded as 8 bytes big endian uint64 which packs [Core.Int64] in lower bits
+// and the number of components in the most significant byte.
+func (c Core) AppendBinary(b []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(b, uint64(c.length)<<56|uint64(c.Int64())), nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. See [Core.AppendBinary] for the format.
+func (c Core) MarshalBinary() ([]byte, error) {
+	return c.AppendBinary(make([]byte, 0, coreBinaryLen))
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+func (c *Core) UnmarshalBinary(data []byte) error {
+	if len(data) != coreBinaryLen {
+		return fmt.Errorf("binary Core must be %d bytes but is %d", coreBinaryLen, len(data))
+	}
+	return c.decodeBinary(data)
+}
+
+func (c *Core) decodeBinary(data []byte) error {
+	packed := binary.BigEndian.Uint64(data)
+	length := int(packed >> 56)
+	decoded, err := CoreFromInt64(int64(packed & (1<<56 - 1)))
+	if err != nil {
+		return err
+	}
+	if length > 4 {
+		return fmt.Errorf("invalid length %d", length)
+	}
+	for i := length; i < 4; i++ {
+		if decoded.component[i] != 0 {
+			return fmt.Errorf("non-zero %q beyond length %d", componentName(i), length)
+		}
+	}
+	decoded.length = length
+	*c = decoded
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = Version{}
+	_ encoding.BinaryAppender    = Version{}
+	_ encoding.BinaryUnmarshaler = (*Version)(nil)
+)
+
+const versionFlagVPrefix = 1 << 0
+
+// AppendBinary implements [encoding.BinaryAppender].
+//
+// v is encoded as the core in the format of [Core.AppendBinary], a flag byte holding v-prefix,
+// then uvarint length prefixed pre-release and build-meta.
+func (v Version) AppendBinary(b []byte) ([]byte, error) {
+	b, _ = v.core.AppendBinary(b)
+	var flags byte
+	if v.vPrefix {
+		flags |= versionFlagVPrefix
+	}
+	b = append(b, flags)
+	b = binary.AppendUvarint(b, uint64(len(v.prerelease)))
+	b = append(b, v.prerelease...)
+	b = binary.AppendUvarint(b, uint64(len(v.build)))
+	b = append(b, v.build...)
+	return b, nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. See [Version.AppendBinary] for the format.
+func (v Version) MarshalBinary() ([]byte, error) {
+	return v.AppendBinary(make([]byte, 0, coreBinaryLen+3+len(v.prerelease)+len(v.build)))
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+// Pre-release and build-meta are validated as [Parse] does.
+func (v *Version) UnmarshalBinary(data []byte) error {
+	if len(data) < coreBinaryLen+1 {
+		return fmt.Errorf("too short")
+	}
+	var decoded Version
+	if err := decoded.core.decodeBinary(data[:coreBinaryLen]); err != nil {
+		return err
+	}
+	flags := data[coreBinaryLen]
+	if flags&^versionFlagVPrefix != 0 {
+		return fmt.Errorf("unknown flags %#x", flags)
+	}
+	decoded.vPrefix = flags&versionFlagVPrefix != 0
+	data = data[coreBinaryLen+1:]
+
+	var strs [2]string
+	for i := range strs {
+		n, read := binary.Uvarint(data)
+		if read <= 0 || n > uint64(len(data)-read) {
+			return fmt.Errorf("invalid length")
+		}
+		strs[i] = string(data[read : read+int(n)])
+		data = data[read+int(n):]
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("trailing %d bytes", len(data))
+	}
+
+	var err error
+	if strs[0] != "" {
+		if decoded.core.length < 3 {
+			return fmt.Errorf("pre-release is only allowed for full or extended version")
+		}
+		if decoded, err = decoded.WithPreRelease(strs[0]); err != nil {
+			return err
+		}
+	}
+	if strs[1] != "" {
+		if decoded.core.length < 3 {
+			return fmt.Errorf("build-meta is only allowed for full or extended version")
+		}
+		if decoded, err = decoded.WithBuild(strs[1]); err != nil {
+			return err
+		}
+	}
+	*v = decoded
+	return nil
+}
@@ -0,0 +1,87 @@
+package exver
+
+import (
+	"testing"
+)
+
+func TestCore_binary(t *testing.T) {
+	for _, input := range []string{"0", "1.2", "1.2.3", "1.2.3.4", "9999.9999.9999.9999"} {
+		c := MustParseCore(input)
+		bin, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatalf("should be nil error but is %v", err)
+		}
+		if len(bin) != 8 {
+			t.Errorf("%q: should be 8 bytes but %d", input, len(bin))
+		}
+		var decoded Core
+		if err := decoded.UnmarshalBinary(bin); err != nil {
+			t.Errorf("%q: should be nil error but is %v", input, err)
+			continue
+		}
+		if decoded != c {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", c, decoded)
+		}
+	}
+
+	for _, input := range [][]byte{
+		nil,
+		make([]byte, 7),
+		make([]byte, 9),
+		{5, 0, 0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 0, 0, 1},
+		{4, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	} {
+		var decoded Core
+		if err := decoded.UnmarshalBinary(input); err == nil {
+			t.Errorf("%v: should be non-nil error but nil", input)
+		}
+	}
+}
+
+func TestVersion_binary(t *testing.T) {
+	for _, input := range []string{"1", "v1.2", "1.2.3-rc.1", "v1.2.3.4-rc.1+build.001", "1.2.3+build"} {
+		v := MustParse(input)
+		bin, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatalf("should be nil error but is %v", err)
+		}
+		var decoded Version
+		if err := decoded.UnmarshalBinary(bin); err != nil {
+			t.Errorf("%q: should be nil error but is %v", input, err)
+			continue
+		}
+		if decoded != v {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", v, decoded)
+		}
+
+		appended, _ := v.AppendBinary([]byte("prefix"))
+		if string(appended[:6]) != "prefix" || string(appended[6:]) != string(bin) {
+			t.Errorf("%q: AppendBinary is not consistent with MarshalBinary", input)
+		}
+
+		for i := range len(bin) {
+			if err := decoded.UnmarshalBinary(bin[:i]); err == nil {
+				t.Errorf("%q: truncated at %d: should be non-nil error but nil", input, i)
+			}
+		}
+		if err := decoded.UnmarshalBinary(append(bin, 0)); err == nil {
+			t.Errorf("%q: trailing data: should be non-nil error but nil", input)
+		}
+	}
+
+	core, _ := MustParseCore("1.2.3").MarshalBinary()
+	short, _ := MustParseCore("1.2").MarshalBinary()
+	for _, input := range [][]byte{
+		append(core, 2, 0, 0),
+		append(core, 0, 2, '.', '.', 0),
+		append(core, 0, 0, 2, 'a', '_'),
+		append(short, 0, 1, 'a', 0),
+		append(short, 0, 0, 1, 'a'),
+	} {
+		var decoded Version
+		if err := decoded.UnmarshalBinary(input); err == nil {
+			t.Errorf("%v: should be non-nil error but nil", input)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package exver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrOverflow is returned when bumping a version component which is already at the maximum of 9999.
+var ErrOverflow = errors.New("overflow")
+
+// BumpMajor returns c with major incremented and other components zeroed.
+func (c Core) BumpMajor() (Core, error) {
+	return c.bump(0)
+}
+
+// BumpMinor returns c with minor incremented and following components zeroed.
+func (c Core) BumpMinor() (Core, error) {
+	return c.bump(1)
+}
+
+// BumpPatch returns c with patch incremented and extra zeroed.
+func (c Core) BumpPatch() (Core, error) {
+	return c.bump(2)
+}
+
+// BumpExtra returns c with extra incremented.
+// The returned Core always has 4 components.
+func (c Core) BumpExtra() (Core, error) {
+	return c.bump(3)
+}
+
+// bump increments idx-th component and zeroes following ones.
+// The number of components is extended to include the bumped one.
+// It returns an error wrapping [ErrOverflow] if the component is already at the maximum.
+func (c Core) bump(idx int) (Core, error) {
+	if c.component[idx] >= componentMax {
+		return c, fmt.Errorf("%w: %q is already %d", ErrOverflow, componentName(idx), componentMax)
+	}
+	c.component[idx]++
+	for i := idx + 1; i < 4; i++ {
+		c.component[i] = 0
+	}
+	c.length = max(c.length, idx+1)
+	return c, nil
+}
+
+// BumpMajor returns the next major version of v, dropping pre-release and build-meta.
+//
+// Like other Bump methods, if v is a pre-release of a version which spells the bumped component
+// and whose lower components are all zero, e.g. 2.0.0-rc.1, the version is considered already bumped
+// and BumpMajor only drops pre-release and build-meta (2.0.0-rc.1 becomes 2.0.0).
+// Otherwise major is incremented and lower components are zeroed (1.2.3 becomes 2.0.0).
+// The number of components is kept, except that it is extended to include the bumped component.
+// v-prefix is kept.
+//
+// It returns an error wrapping [ErrOverflow] if major is already 9999.
+func (v Version) BumpMajor() (Version, error) {
+	return v.bump(0)
+}
+
+// BumpMinor is like [Version.BumpMajor] but bumps minor:
+// 1.2.3 becomes 1.3.0 and 1.3.0-rc.1 becomes 1.3.0.
+func (v Version) BumpMinor() (Version, error) {
+	return v.bump(1)
+}
+
+// BumpPatch is like [Version.BumpMajor] but bumps patch:
+// 1.2.3 becomes 1.2.4, 1.2.3.4 becomes 1.2.4.0 and 1.2.4-rc.1 becomes 1.2.4.
+func (v Version) BumpPatch() (Version, error) {
+	return v.bump(2)
+}
+
+// BumpExtra is like [Version.BumpMajor] but bumps extra:
+// 1.2.3 becomes 1.2.3.1 and 1.2.3.1-rc.1 becomes 1.2.3.1.
+// A pre-release without extra is not considered already bumped since it precedes the release of its core:
+// 1.2.3-rc.1 becomes 1.2.3.1, not 1.2.3.0.
+// The returned version always has 4 components.
+func (v Version) BumpExtra() (Version, error) {
+	return v.bump(3)
+}
+
+func (v Version) bump(idx int) (Version, error) {
+	if v.prerelease != "" && idx < v.core.length && v.lowerZero(idx) {
+		v.prerelease, v.build = "", ""
+		return v, nil
+	}
+	core, err := v.core.bump(idx)
+	if err != nil {
+		return v, err
+	}
+	v.core = core
+	v.prerelease, v.build = "", ""
+	return v, nil
+}
+
+// lowerZero reports whether components after idx-th are all zero.
+func (v Version) lowerZero(idx int) bool {
+	for i := idx + 1; i < 4; i++ {
+		if v.core.component[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BumpPreRelease returns the next pre-release version of v, dropping build-meta.
+//
+// If v has pre-release and label is empty or v's pre-release starts with label,
+// the right-most numeric identifier of pre-release is incremented (rc.1 becomes rc.2),
+// or ".1" is appended if it has no numeric identifier (rc becomes rc.1).
+// If v's pre-release does not start with label, pre-release is replaced with label + ".1".
+// If v has no pre-release, the last component, patch or extra, is bumped
+// and pre-release is set to label + ".1" (1.2.3 becomes 1.2.4-rc.1);
+// label must not be empty in this case.
+//
+// label must be valid pre-release, e.g. "rc" or "alpha.pre".
+// BumpPreRelease returns an error if the result is not greater than v,
+// e.g. bumping 1.2.3-rc.1 with label "beta",
+// or the bumped component overflows.
+func (v Version) BumpPreRelease(label string) (Version, error) {
+	if label != "" {
+		if _, rest, ok := preRelease(label); !ok || rest != "" {
+			return v, fmt.Errorf("invalid pre-release label %q", label)
+		}
+	}
+
+	bumped := v
+	bumped.build = ""
+	switch {
+	case v.prerelease == "":
+		if label == "" {
+			return v, fmt.Errorf("empty label for version without pre-release")
+		}
+		idx := 2
+		if v.core.length == 4 {
+			idx = 3
+		}
+		core, err := v.core.bump(idx)
+		if err != nil {
+			return v, err
+		}
+		bumped.core = core
+		bumped.prerelease = label + ".1"
+	case label == "" || v.prerelease == label || strings.HasPrefix(v.prerelease, label+"."):
+		pre, err := incrementLastNumeric(v.prerelease)
+		if err != nil {
+			return v, err
+		}
+		bumped.prerelease = pre
+	default:
+		bumped.prerelease = label + ".1"
+	}
+
+	if bumped.Compare(v) <= 0 {
+		return v, fmt.Errorf("bumped pre-release %q is not greater than %q", bumped.prerelease, v.prerelease)
+	}
+	return bumped, nil
+}
+
+func incrementLastNumeric(prerelease string) (string, error) {
+	idents := strings.Split(prerelease, ".")
+	for i := len(idents) - 1; i >= 0; i-- {
+		if !isNum(idents[i]) {
+			continue
+		}
+		n, err := strconv.ParseUint(idents[i], 10, 64)
+		if err != nil || n == ^uint64(0) {
+			return "", fmt.Errorf("%w: pre-release identifier %q", ErrOverflow, idents[i])
+		}
+		idents[i] = strconv.FormatUint(n+1, 10)
+		return strings.Join(idents, "."), nil
+	}
+	return prerelease + ".1", nil
+}
+
+// Release returns v without pre-release, i.e. the version v is a pre-release of.
+// Build-meta and v-prefix are kept.
+func (v Version) Release() Version {
+	v.prerelease = ""
+	return v
+}
@@ -0,0 +1,157 @@
+package exver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCore_Bump(t *testing.T) {
+	for _, tc := range []struct {
+		in                         string
+		major, minor, patch, extra string
+	}{
+		{"1", "2", "1.1", "1.0.1", "1.0.0.1"},
+		{"1.2", "2.0", "1.3", "1.2.1", "1.2.0.1"},
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+		{"1.2.3.4", "2.0.0.0", "1.3.0.0", "1.2.4.0", "1.2.3.5"},
+	} {
+		c := MustParseCore(tc.in)
+		for _, b := range []struct {
+			fn       func() (Core, error)
+			expected string
+		}{
+			{c.BumpMajor, tc.major},
+			{c.BumpMinor, tc.minor},
+			{c.BumpPatch, tc.patch},
+			{c.BumpExtra, tc.extra},
+		} {
+			bumped, err := b.fn()
+			if err != nil {
+				t.Errorf("%q: should be nil error but is %v", tc.in, err)
+			}
+			if bumped.String() != b.expected {
+				t.Errorf("%q: not equal:\nexpected = %s\nactual = %s", tc.in, b.expected, bumped.String())
+			}
+		}
+	}
+
+	bumped, err := MustParseCore("1.9999.3").BumpMinor()
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("should be ErrOverflow but is %v", err)
+	}
+	if bumped.String() != "1.9999.3" {
+		t.Errorf("must be unmodified on error but is %s", bumped)
+	}
+	if _, err := MustParseCore("1.9999.3").BumpPatch(); err != nil {
+		t.Errorf("should be nil error but is %v", err)
+	}
+}
+
+func TestVersion_Bump(t *testing.T) {
+	for _, tc := range []struct {
+		in                         string
+		major, minor, patch, extra string
+	}{
+		{"v1.2.3", "v2.0.0", "v1.3.0", "v1.2.4", "v1.2.3.1"},
+		{"1.2.3+meta", "2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+		{"1.2.3-rc.1", "2.0.0", "1.3.0", "1.2.3", "1.2.3.1"},
+		{"1.2.0-rc.1", "2.0.0", "1.2.0", "1.2.0", "1.2.0.1"},
+		{"2.0.0-rc.1+meta", "2.0.0", "2.0.0", "2.0.0", "2.0.0.1"},
+		{"1.2.3.0-rc.1", "2.0.0.0", "1.3.0.0", "1.2.3.0", "1.2.3.0"},
+		{"1.2.3.4-rc.1", "2.0.0.0", "1.3.0.0", "1.2.4.0", "1.2.3.4"},
+		{"1.2", "2.0", "1.3", "1.2.1", "1.2.0.1"},
+	} {
+		v := MustParse(tc.in)
+		for _, b := range []struct {
+			fn       func() (Version, error)
+			expected string
+		}{
+			{v.BumpMajor, tc.major},
+			{v.BumpMinor, tc.minor},
+			{v.BumpPatch, tc.patch},
+			{v.BumpExtra, tc.extra},
+		} {
+			bumped, err := b.fn()
+			if err != nil {
+				t.Errorf("%q: should be nil error but is %v", tc.in, err)
+			}
+			if bumped.String() != b.expected {
+				t.Errorf("%q: not equal:\nexpected = %s\nactual = %s", tc.in, b.expected, bumped.String())
+			}
+			// bumped version is greater than v and not less than the release v is a pre-release of.
+			if bumped.Compare(v.Release()) < 0 || bumped.Compare(v) <= 0 {
+				t.Errorf("%q: bumped %s is not greater than v and its release", tc.in, bumped)
+			}
+		}
+	}
+
+	if _, err := MustParse("9999.0.0").BumpMajor(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("should be ErrOverflow but is %v", err)
+	}
+	if _, err := MustParse("1.2.3.9999").BumpExtra(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("should be ErrOverflow but is %v", err)
+	}
+	// already bumped by pre-release, no overflow.
+	if _, err := MustParse("9999.0.0-rc.1").BumpMajor(); err != nil {
+		t.Errorf("should be nil error but is %v", err)
+	}
+}
+
+func TestVersion_BumpPreRelease(t *testing.T) {
+	for _, tc := range []struct {
+		in, label, expected string
+	}{
+		{"v1.2.3", "rc", "v1.2.4-rc.1"},
+		{"1.2.3.4", "rc", "1.2.3.5-rc.1"},
+		{"1.2.3-rc.1", "rc", "1.2.3-rc.2"},
+		{"1.2.3-rc.1+meta", "", "1.2.3-rc.2"},
+		{"1.2.3-rc.9", "", "1.2.3-rc.10"},
+		{"1.2.3-rc", "rc", "1.2.3-rc.1"},
+		{"1.2.3-rc.1.foo", "rc", "1.2.3-rc.2.foo"},
+		{"1.2.3-alpha.3", "beta", "1.2.3-beta.1"},
+		{"1.2.3-alpha.pre.3", "alpha.pre", "1.2.3-alpha.pre.4"},
+		{"1.2.3-devel", "", "1.2.3-devel.1"},
+	} {
+		bumped, err := MustParse(tc.in).BumpPreRelease(tc.label)
+		if err != nil {
+			t.Errorf("%q, %q: should be nil error but is %v", tc.in, tc.label, err)
+			continue
+		}
+		if bumped.String() != tc.expected {
+			t.Errorf("%q, %q: not equal:\nexpected = %s\nactual = %s", tc.in, tc.label, tc.expected, bumped.String())
+		}
+	}
+
+	for _, tc := range []struct {
+		in, label string
+	}{
+		{"1.2.3", ""},
+		{"1.2.3", "rc..1"},
+		{"1.2.3", "あ"},
+		{"1.2.3-rc.1", "beta"},
+		{"1.2.9999", "rc"},
+		{"1.2.3-rc.18446744073709551615", "rc"},
+	} {
+		bumped, err := MustParse(tc.in).BumpPreRelease(tc.label)
+		if err == nil {
+			t.Errorf("%q, %q: should be non-nil error but nil", tc.in, tc.label)
+		}
+		if bumped.String() != tc.in {
+			t.Errorf("%q, %q: must be unmodified on error but is %s", tc.in, tc.label, bumped)
+		}
+	}
+}
+
+func TestVersion_Release(t *testing.T) {
+	for _, tc := range []struct {
+		in, expected string
+	}{
+		{"v1.2.3-rc.1", "v1.2.3"},
+		{"1.2.3-rc.1+meta", "1.2.3+meta"},
+		{"1.2.3.4", "1.2.3.4"},
+	} {
+		if released := MustParse(tc.in).Release(); released.String() != tc.expected {
+			t.Errorf("%q: not equal:\nexpected = %s\nactual = %s", tc.in, tc.expected, released.String())
+		}
+	}
+}
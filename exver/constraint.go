@@ -0,0 +1,388 @@
+package exver
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Constraint is a set of version ranges which a [Version] can be checked against.
+//
+// The text form of Constraint is one or more comparator sets separated by "||".
+// A version satisfies the constraint if it satisfies every comparator in any of the sets.
+// Comparators in a set are separated by spaces or commas.
+//
+// A comparator is an optional operator followed by a version pattern.
+// A pattern is like a [Version] but any component may be replaced with a wildcard ("x", "X" or "*")
+// or omitted, in which case it is a partial pattern matching every version sharing the specified components.
+// Components after a wildcard must also be wildcards.
+// Omitted MAJOR, MINOR and PATCH are wildcards, while omitted EXTRA is 0:
+// "1.2" matches 1.2.0 and 1.2.9.9, "1.2.3" matches only 1.2.3 (or 1.2.3.0) and "1.2.3.x" matches 1.2.3.9 too.
+// Pre-release is only allowed in patterns without wildcards; build-meta is allowed but ignored.
+//
+// Operators are:
+//
+//	=   equal to, or within a partial pattern; same as no operator
+//	!=  not equal to, or not within a partial pattern
+//	>   greater than; for a partial pattern, greater than every version within it (">1.2" means ">=1.3.0-0")
+//	>=  greater than or equal to
+//	<   less than; for a partial pattern, less than every version within it ("<1.2" means "<1.2.0-0")
+//	<=  less than or equal to; for a partial pattern, less than or equal to any version within it
+//	~   allows changes of the last component, or PATCH if the pattern has 3 components or less:
+//	    "~1.2.3" means ">=1.2.3 <1.3.0-0", "~1.2.3.4" means ">=1.2.3.4 <1.2.4-0", "~1" means ">=1.0.0 <2.0.0-0"
+//	^   allows changes not modifying the left-most non-zero component:
+//	    "^1.2.3" means ">=1.2.3 <2.0.0-0", "^0.2.3" means ">=0.2.3 <0.3.0-0", "^0.0.0.3" means ">=0.0.0.3 <0.0.0.4-0"
+//
+// Versions are compared by [Version.Compare], except that the number of version fields does not matter,
+// i.e. 1.2 is equal to 1.2.0.
+// Thus pre-releases are matched by their precedence: 1.3.0-rc.1 satisfies ">=1.2.0 <1.3.0"
+// but not "^1.2.0", whose upper bound is the least pre-release of 2.0.0.
+// v-prefix and build-meta are ignored.
+//
+// The zero value of Constraint matches every version.
+type Constraint struct {
+	sets [][]comparator
+}
+
+type comparator struct {
+	// text is the text form of the comparator, as written but without spaces.
+	text string
+	// lo and hi bound matching versions. Unset bounds are unbounded.
+	lo, hi bound
+	// negate inverts the result of matching.
+	negate bool
+	// none is true if the comparator matches no version.
+	none bool
+}
+
+type bound struct {
+	set       bool
+	inclusive bool
+	v         Version
+}
+
+// ParseConstraint parses s as a [Constraint].
+func ParseConstraint(s string) (Constraint, error) {
+	var c Constraint
+	for i, set := range strings.Split(s, "||") {
+		comparators, err := parseComparatorSet(set)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("comparator set %d: %w", i, err)
+		}
+		c.sets = append(c.sets, comparators)
+	}
+	return c, nil
+}
+
+// MustParseConstraint is like [ParseConstraint] but panics if any error occurs.
+func MustParseConstraint(s string) Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func parseComparatorSet(s string) ([]comparator, error) {
+	var comparators []comparator
+	isSep := func(b byte) bool { return b == ' ' || b == '\t' || b == ',' }
+	for {
+		for len(s) > 0 && isSep(s[0]) {
+			s = s[1:]
+		}
+		if len(s) == 0 {
+			break
+		}
+		var op string
+		for len(s) > 0 && strings.IndexByte("<>=!~^", s[0]) >= 0 {
+			op += s[:1]
+			s = s[1:]
+		}
+		for len(s) > 0 && (s[0] == ' ' || s[0] == '\t') {
+			s = s[1:]
+		}
+		i := 0
+		for i < len(s) && !isSep(s[i]) && s[i] != '|' {
+			i++
+		}
+		pattern := s[:i]
+		s = s[i:]
+		c, err := parseComparator(op, pattern)
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, c)
+	}
+	if len(comparators) == 0 {
+		return nil, fmt.Errorf("empty")
+	}
+	return comparators, nil
+}
+
+func parseComparator(op, pattern string) (comparator, error) {
+	switch op {
+	case "", "=", "!=", ">", ">=", "<", "<=", "~", "^":
+	default:
+		return comparator{}, fmt.Errorf("unknown operator %q", op)
+	}
+
+	base, n, partial, err := parsePattern(pattern)
+	if err != nil {
+		return comparator{}, fmt.Errorf("%q: %w", pattern, err)
+	}
+
+	c := comparator{text: op + pattern}
+	// upper is the least version greater than every version within the partial pattern.
+	upper := func() bound {
+		return incremented(base, n-1)
+	}
+	lower := bound{set: n > 0, inclusive: true, v: base}
+
+	switch op {
+	case "", "=", "!=":
+		if partial {
+			c.lo, c.hi = lower, upper()
+		} else {
+			c.lo, c.hi = lower, lower
+		}
+		c.negate = op == "!="
+	case ">":
+		if partial {
+			c.lo = upper()
+			c.lo.inclusive = true
+			c.none = !c.lo.set
+		} else {
+			c.lo = bound{set: true, v: base}
+		}
+	case ">=":
+		c.lo = lower
+	case "<":
+		if n == 0 {
+			c.none = true
+		}
+		c.hi = bound{set: n > 0, v: base}
+		if partial {
+			c.hi.v.prerelease = "0"
+		}
+	case "<=":
+		if partial {
+			c.hi = upper()
+		} else {
+			c.hi = lower
+		}
+	case "~":
+		idx := 1
+		switch n {
+		case 1:
+			idx = 0
+		case 4:
+			idx = 2
+		}
+		c.lo, c.hi = lower, incremented(base, min(idx, n-1))
+	case "^":
+		idx := n - 1
+		for i := range n {
+			if base.core.component[i] != 0 {
+				idx = i
+				break
+			}
+		}
+		c.lo, c.hi = lower, incremented(base, idx)
+	}
+	return c, nil
+}
+
+// parsePattern parses a version pattern.
+// n is the number of components specified before any wildcard.
+// partial reports whether the pattern is partial, i.e. it has wildcards or less than 3 components.
+func parsePattern(s string) (base Version, n int, partial bool, err error) {
+	if len(s) == 0 {
+		return Version{}, 0, false, fmt.Errorf("missing version")
+	}
+	if s[0] == 'v' {
+		s = s[1:]
+	}
+	var wildcard bool
+	for i := range 4 {
+		if i > 0 {
+			if len(s) == 0 || s[0] != '.' {
+				break
+			}
+			s = s[1:]
+		}
+		if len(s) > 0 && (s[0] == 'x' || s[0] == 'X' || s[0] == '*') {
+			wildcard = true
+			s = s[1:]
+			continue
+		}
+		if wildcard {
+			return Version{}, 0, false, fmt.Errorf("%q after wildcard", componentName(i))
+		}
+		var num string
+		var ok bool
+		num, s, ok = numericIdentifier(s)
+		if !ok {
+			return Version{}, 0, false, fmt.Errorf("missing %q", componentName(i))
+		}
+		if len(num) > 4 || parseComponent(num) > componentMax {
+			return Version{}, 0, false, fmt.Errorf("%q too large: larger than %d", componentName(i), componentMax)
+		}
+		base.core.component[i] = parseComponent(num)
+		n++
+	}
+	base.core.length = max(n, 3)
+	partial = wildcard || n < 3
+
+	if len(s) > 0 && s[0] == '-' {
+		if partial {
+			return Version{}, 0, false, fmt.Errorf("pre-release in partial version")
+		}
+		var ok bool
+		base.prerelease, s, ok = preRelease(s[1:])
+		if !ok {
+			return Version{}, 0, false, fmt.Errorf("invalid \"pre-release\"")
+		}
+	}
+	if len(s) > 0 && s[0] == '+' {
+		var ok bool
+		_, s, ok = build(s[1:])
+		if !ok {
+			return Version{}, 0, false, fmt.Errorf("invalid \"build\"")
+		}
+	}
+	if len(s) > 0 {
+		return Version{}, 0, false, fmt.Errorf("extra string %q after version", s)
+	}
+	return base, n, partial, nil
+}
+
+func parseComponent(num string) uint16 {
+	var v uint16
+	for i := range len(num) {
+		v = v*10 + uint16(num[i]-'0')
+	}
+	return v
+}
+
+// incremented returns an exclusive bound at the least pre-release of the version
+// where v's idx-th component is incremented and following components are zeroed.
+// If incrementing overflows every component up to the major, the returned bound is unset.
+func incremented(v Version, idx int) bound {
+	if idx < 0 {
+		return bound{}
+	}
+	comp := v.core.component
+	for i := idx + 1; i < 4; i++ {
+		comp[i] = 0
+	}
+	for ; idx >= 0; idx-- {
+		if comp[idx] < componentMax {
+			comp[idx]++
+			return bound{
+				set: true,
+				v:   Version{core: Core{component: comp, length: 3}, prerelease: "0"},
+			}
+		}
+		comp[idx] = 0
+	}
+	return bound{}
+}
+
+// Check reports whether v satisfies c.
+func (c Constraint) Check(v Version) bool {
+	if len(c.sets) == 0 {
+		return true
+	}
+	for _, set := range c.sets {
+		matched := true
+		for _, comp := range set {
+			if !comp.match(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparator) match(v Version) bool {
+	if c.none {
+		return false
+	}
+	ok := true
+	if c.lo.set {
+		r := compareIgnoringLength(v, c.lo.v)
+		ok = r > 0 || (r == 0 && c.lo.inclusive)
+	}
+	if ok && c.hi.set {
+		r := compareIgnoringLength(v, c.hi.v)
+		ok = r < 0 || (r == 0 && c.hi.inclusive)
+	}
+	return ok != c.negate
+}
+
+func compareIgnoringLength(v, u Version) int {
+	v.core.length, u.core.length = 4, 4
+	return v.Compare(u)
+}
+
+// String returns the text form of c.
+// Comparators are joined by a space and sets by " || ".
+// It returns "*" for the zero value.
+func (c Constraint) String() string {
+	if len(c.sets) == 0 {
+		return "*"
+	}
+	var builder strings.Builder
+	for i, set := range c.sets {
+		if i > 0 {
+			builder.WriteString(" || ")
+		}
+		for j, comp := range set {
+			if j > 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString(comp.text)
+		}
+	}
+	return builder.String()
+}
+
+var (
+	_ encoding.TextMarshaler   = Constraint{}
+	_ encoding.TextUnmarshaler = (*Constraint)(nil)
+)
+
+func (c Constraint) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Constraint) UnmarshalText(text []byte) error {
+	parsed, err := ParseConstraint(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+var (
+	_ json.Marshaler   = Constraint{}
+	_ json.Unmarshaler = (*Constraint)(nil)
+)
+
+func (c Constraint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Constraint) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,236 @@
+package exver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseConstraint_bad(t *testing.T) {
+	for _, tc := range []string{
+		"",
+		"||",
+		">=1.2.3 ||",
+		"=>1.2.3",
+		"1.x.3",
+		"1.2-rc.1",
+		"1.2.x-rc.1",
+		">=1.2.3.4.5",
+		">=10000",
+		">=01.2.3",
+		"1.2.3foo",
+		"1.2.3 | 2.0.0",
+		">= ",
+	} {
+		if _, err := ParseConstraint(tc); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", tc)
+		}
+	}
+}
+
+func TestConstraint_Check(t *testing.T) {
+	for _, tc := range []struct {
+		constraint string
+		match      []string
+		unmatch    []string
+	}{
+		{
+			"*",
+			[]string{"0.0.0", "1.2.3", "9999.9999.9999.9999", "1.0.0-rc.1"},
+			nil,
+		},
+		{
+			">=1.2.3 <2.0.0",
+			[]string{"1.2.3", "v1.2.3", "1.2.3.1", "1.9.9", "1.5.0-rc.1", "2.0.0-rc.1", "1.2.3+meta"},
+			[]string{"1.2.2", "1.2.3-rc.1", "2.0.0", "2.0.0.1"},
+		},
+		{
+			">=1.2.3, <2.0.0",
+			[]string{"1.2.3", "1.9.9"},
+			[]string{"1.2.2", "2.0.0"},
+		},
+		{
+			"^1.2",
+			[]string{"1.2.0", "1.2", "1.3.0", "1.9999.0.1"},
+			[]string{"1.1.9", "1.2.0-rc.1", "2.0.0-0", "2.0.0"},
+		},
+		{
+			"^0.2.3",
+			[]string{"0.2.3", "0.2.9"},
+			[]string{"0.2.2", "0.3.0-rc.1", "0.3.0"},
+		},
+		{
+			"^0.0.3",
+			[]string{"0.0.3", "0.0.3.9"},
+			[]string{"0.0.4"},
+		},
+		{
+			"^0.0.0.3",
+			[]string{"0.0.0.3"},
+			[]string{"0.0.0.4", "0.0.1"},
+		},
+		{
+			"^1.2.3-beta.2",
+			[]string{"1.2.3-beta.2", "1.2.3-beta.11", "1.2.3", "1.5.0"},
+			[]string{"1.2.3-beta.1", "1.2.3-alpha"},
+		},
+		{
+			"~1.2.3",
+			[]string{"1.2.3", "1.2.9", "1.2.3.4"},
+			[]string{"1.2.2", "1.3.0", "1.3.0-rc.1"},
+		},
+		{
+			"~1.2.3.4",
+			[]string{"1.2.3.4", "1.2.3.9"},
+			[]string{"1.2.3.3", "1.2.4", "1.2.4-rc.1"},
+		},
+		{
+			"~1",
+			[]string{"1.0.0", "1.9.9"},
+			[]string{"0.9.9", "2.0.0"},
+		},
+		{
+			"1.2.x",
+			[]string{"1.2.0", "1.2.9", "1.2.9.9"},
+			[]string{"1.1.9", "1.3.0", "1.2.0-rc.1"},
+		},
+		{
+			"1.2",
+			[]string{"1.2.0", "1.2.9"},
+			[]string{"1.3.0"},
+		},
+		{
+			"1.2.3",
+			[]string{"1.2.3", "1.2.3.0", "v1.2.3+meta"},
+			[]string{"1.2.3.1", "1.2.3-rc.1"},
+		},
+		{
+			"1.2.3.x",
+			[]string{"1.2.3", "1.2.3.9"},
+			[]string{"1.2.4", "1.2.2.9"},
+		},
+		{
+			"=1.2.3-rc.1",
+			[]string{"1.2.3-rc.1", "1.2.3-rc.1+meta"},
+			[]string{"1.2.3-rc.2", "1.2.3"},
+		},
+		{
+			"!=1.2.3",
+			[]string{"1.2.2", "1.2.3.1", "1.2.3-rc.1"},
+			[]string{"1.2.3"},
+		},
+		{
+			"!=1.2.x",
+			[]string{"1.1.0", "1.3.0"},
+			[]string{"1.2.0", "1.2.5"},
+		},
+		{
+			">1.2",
+			[]string{"1.3.0", "1.3.0-rc.1"},
+			[]string{"1.2.9", "1.2.9.9"},
+		},
+		{
+			"<1.2",
+			[]string{"1.1.9", "1.1.9999.9999"},
+			[]string{"1.2.0-rc.1", "1.2.0"},
+		},
+		{
+			"<=1.2",
+			[]string{"1.2.0", "1.2.9.9"},
+			[]string{"1.3.0-rc.1", "1.3.0"},
+		},
+		{
+			">1.2.3",
+			[]string{"1.2.3.1", "1.2.4"},
+			[]string{"1.2.3", "1.2.3-rc.1"},
+		},
+		{
+			"<=1.2.3",
+			[]string{"1.2.3", "1.2.3-rc.1"},
+			[]string{"1.2.3.1"},
+		},
+		{
+			"<1.0.0 || >=2.0.0 <2.1.0 || 3.x",
+			[]string{"0.9.0", "2.0.5", "3.5.0"},
+			[]string{"1.0.0", "2.1.0", "4.0.0"},
+		},
+		{
+			"9999.x",
+			[]string{"9999.0.0", "9999.9999.9999.9999"},
+			[]string{"9998.9999.9999"},
+		},
+		{
+			">9999.x",
+			nil,
+			[]string{"9999.9999.9999.9999"},
+		},
+		{
+			"<*",
+			nil,
+			[]string{"0.0.0"},
+		},
+	} {
+		c, err := ParseConstraint(tc.constraint)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.constraint, err)
+			continue
+		}
+		for _, v := range tc.match {
+			if !c.Check(MustParse(v)) {
+				t.Errorf("%q: should match %q", tc.constraint, v)
+			}
+		}
+		for _, v := range tc.unmatch {
+			if c.Check(MustParse(v)) {
+				t.Errorf("%q: should not match %q", tc.constraint, v)
+			}
+		}
+	}
+
+	var zero Constraint
+	if !zero.Check(MustParse("1.2.3")) {
+		t.Errorf("zero Constraint should match every version")
+	}
+}
+
+func TestConstraint_String_MarshalText_MarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{">=1.2.3 <2.0.0", ">=1.2.3 <2.0.0"},
+		{">= 1.2.3,<2.0.0", ">=1.2.3 <2.0.0"},
+		{"  ^1.2||~v1.2.3.4  ", "^1.2 || ~v1.2.3.4"},
+		{"1.2.x || *", "1.2.x || *"},
+	} {
+		c, err := ParseConstraint(tc.in)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.in, err)
+			continue
+		}
+		if s := c.String(); s != tc.out {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.out, s)
+		}
+		if bin, _ := c.MarshalText(); string(bin) != tc.out {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.out, bin)
+		}
+
+		bin, err := json.Marshal(c)
+		if err != nil {
+			t.Errorf("marshaling failed: %v", err)
+		}
+		var c2 Constraint
+		if err := json.Unmarshal(bin, &c2); err != nil {
+			t.Errorf("unmarshaling %s failed: %v", bin, err)
+		}
+		if c2.String() != tc.out {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.out, c2.String())
+		}
+	}
+
+	if s := (Constraint{}).String(); s != "*" {
+		t.Errorf("zero Constraint should be \"*\" but is %q", s)
+	}
+	var c Constraint
+	if err := c.UnmarshalJSON([]byte(`">=1.0.0 <"`)); err == nil {
+		t.Errorf("should be non-nil error but nil")
+	}
+}
@@ -0,0 +1,115 @@
+package exver
+
+import (
+	"fmt"
+	"iter"
+)
+
+// ChangeKind classifies a change between two versions by the first component that differs.
+type ChangeKind int
+
+const (
+	// ChangeNone means versions are same, except for v-prefix and trailing zero components.
+	ChangeNone ChangeKind = iota
+	ChangeMajor
+	ChangeMinor
+	ChangePatch
+	ChangeExtra
+	// ChangePreRelease means cores are same but pre-releases differ.
+	ChangePreRelease
+	// ChangeBuild means only build-metas differ.
+	ChangeBuild
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeNone:
+		return "none"
+	case ChangeMajor:
+		return "major"
+	case ChangeMinor:
+		return "minor"
+	case ChangePatch:
+		return "patch"
+	case ChangeExtra:
+		return "extra"
+	case ChangePreRelease:
+		return "pre-release"
+	case ChangeBuild:
+		return "build-meta"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Change describes a change from a version to another.
+type Change struct {
+	Kind ChangeKind
+	// Direction is +1 if the change is an upgrade, -1 if a downgrade,
+	// or 0 if versions have same precedence, i.e. Kind is ChangeNone or ChangeBuild.
+	Direction int
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Direction > 0:
+		return c.Kind.String() + " upgrade"
+	case c.Direction < 0:
+		return c.Kind.String() + " downgrade"
+	default:
+		return c.Kind.String()
+	}
+}
+
+// Diff classifies the change from a to b.
+//
+// Cores are compared as [Core.Compare] does: missing components are treated as 0,
+// so the change from 1.2 to 1.2.0 is ChangeNone.
+// The pre-release is compared only when cores are same, and the build-meta only when pre-releases are also same.
+//
+// For example, the change from v1.2.3 to v1.4.0 is a minor upgrade
+// and one from 1.2.3-rc.1 to 1.2.3 is a pre-release upgrade.
+func Diff(a, b Version) Change {
+	var kind ChangeKind
+	switch {
+	case a.core.Compare(b.core) != 0:
+		for i := range 4 {
+			if a.core.component[i] != b.core.component[i] {
+				kind = ChangeMajor + ChangeKind(i)
+				break
+			}
+		}
+	case a.prerelease != b.prerelease:
+		kind = ChangePreRelease
+	case a.build != b.build:
+		kind = ChangeBuild
+	}
+	return Change{Kind: kind, Direction: compareIgnoringLength(b, a)}
+}
+
+// Between returns an iterator over cores after a up to and including b,
+// stepping by incrementing the component specified by step and zeroing following ones.
+// step must be one of ChangeMajor, ChangeMinor, ChangePatch and ChangeExtra, otherwise Between panics.
+//
+// Cores are compared by [Core.Compare].
+// For example, Between of 1.2.3 and 1.4.0 by ChangeMinor yields 1.3.0 and 1.4.0.
+// The iteration stops when the component reaches the maximum of 9999.
+func Between(a, b Core, step ChangeKind) iter.Seq[Core] {
+	if step < ChangeMajor || ChangeExtra < step {
+		panic(fmt.Sprintf("invalid step %s", step))
+	}
+	idx := int(step - ChangeMajor)
+	return func(yield func(Core) bool) {
+		c := a
+		for {
+			var err error
+			c, err = c.bump(idx)
+			if err != nil || c.Compare(b) > 0 {
+				return
+			}
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package exver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected Change
+	}{
+		{"v1.2.3", "v1.4.0", Change{ChangeMinor, +1}},
+		{"v1.4.0", "v1.2.3", Change{ChangeMinor, -1}},
+		{"1.2.3", "2.0.0-rc.1", Change{ChangeMajor, +1}},
+		{"1.2.3", "1.2.4", Change{ChangePatch, +1}},
+		{"1.2.3", "1.2.3.1", Change{ChangeExtra, +1}},
+		{"1.2.3.1", "1.2.3", Change{ChangeExtra, -1}},
+		{"1.2.3-rc.1", "1.2.3", Change{ChangePreRelease, +1}},
+		{"1.2.3-rc.2", "1.2.3-rc.10", Change{ChangePreRelease, +1}},
+		{"1.2.3-beta", "1.2.3-alpha", Change{ChangePreRelease, -1}},
+		{"1.2.3+a", "1.2.3+b", Change{ChangeBuild, 0}},
+		{"1.2.3-rc.1+a", "1.2.3-rc.1", Change{ChangeBuild, 0}},
+		{"1.2.3", "v1.2.3", Change{ChangeNone, 0}},
+		{"1.2", "1.2.0", Change{ChangeNone, 0}},
+		{"1.2.0.0", "1.2", Change{ChangeNone, 0}},
+	} {
+		actual := Diff(MustParse(tc.a), MustParse(tc.b))
+		if actual != tc.expected {
+			t.Errorf("Diff(%q, %q): not equal:\nexpected = %s\nactual = %s", tc.a, tc.b, tc.expected, actual)
+		}
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	for _, tc := range []struct {
+		change   Change
+		expected string
+	}{
+		{Change{ChangeMinor, +1}, "minor upgrade"},
+		{Change{ChangePreRelease, -1}, "pre-release downgrade"},
+		{Change{ChangeBuild, 0}, "build-meta"},
+		{Change{ChangeKind(100), 0}, "ChangeKind(100)"},
+	} {
+		if actual := tc.change.String(); actual != tc.expected {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.expected, actual)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		step     ChangeKind
+		expected []string
+	}{
+		{"1.2.3", "1.4.0", ChangeMinor, []string{"1.3.0", "1.4.0"}},
+		{"1.2.3", "1.4.1", ChangeMinor, []string{"1.3.0", "1.4.0"}},
+		{"1.2.3", "1.2.6", ChangePatch, []string{"1.2.4", "1.2.5", "1.2.6"}},
+		{"1", "3.1", ChangeMajor, []string{"2", "3"}},
+		{"1.2", "1.2.0.2", ChangeExtra, []string{"1.2.0.1", "1.2.0.2"}},
+		{"1.2.3", "1.2.3", ChangePatch, nil},
+		{"1.4.0", "1.2.3", ChangeMinor, nil},
+		{"1.9998.0", "2.0.0", ChangeMinor, []string{"1.9999.0"}},
+	} {
+		var actual []string
+		for c := range Between(MustParseCore(tc.a), MustParseCore(tc.b), tc.step) {
+			actual = append(actual, c.String())
+		}
+		if !slices.Equal(actual, tc.expected) {
+			t.Errorf("Between(%q, %q, %s): not equal:\nexpected = %v\nactual = %v", tc.a, tc.b, tc.step, tc.expected, actual)
+		}
+	}
+
+	var n int
+	for range Between(MustParseCore("0.0.0"), MustParseCore("1.0.0"), ChangePatch) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("should stop at 3 but %d", n)
+	}
+
+	for _, step := range []ChangeKind{ChangeNone, ChangePreRelease, ChangeBuild} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: should panic", step)
+				}
+			}()
+			Between(MustParseCore("1.0.0"), MustParseCore("2.0.0"), step)
+		}()
+	}
+}
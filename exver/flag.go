@@ -0,0 +1,84 @@
+package exver
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"log/slog"
+)
+
+// Flag returns a [flag.Getter] which sets p by parsing command line arguments.
+//
+//	var v exver.Version
+//	flag.Var(exver.Flag(&v), "version", "version to release")
+//
+// Get returns the current value of *p.
+func Flag[T Version | Core | Constraint | GoVersion](p *T) flag.Getter {
+	return &flagValue[T]{p: p}
+}
+
+type flagValue[T Version | Core | Constraint | GoVersion] struct {
+	p *T
+}
+
+func (f *flagValue[T]) Set(s string) error {
+	return any(f.p).(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+}
+
+func (f *flagValue[T]) String() string {
+	// flag.PrintDefaults calls String on the zero value.
+	if f == nil || f.p == nil {
+		return ""
+	}
+	return any(*f.p).(fmt.Stringer).String()
+}
+
+func (f *flagValue[T]) Get() any {
+	return *f.p
+}
+
+var (
+	_ slog.LogValuer = Version{}
+	_ slog.LogValuer = Core{}
+	_ slog.LogValuer = Constraint{}
+)
+
+// LogValue implements [slog.LogValuer]. v is logged as its string form.
+func (v Version) LogValue() slog.Value {
+	return slog.StringValue(v.String())
+}
+
+// LogValue implements [slog.LogValuer]. c is logged as its string form.
+func (c Core) LogValue() slog.Value {
+	return slog.StringValue(c.String())
+}
+
+// LogValue implements [slog.LogValuer]. c is logged as its string form.
+func (c Constraint) LogValue() slog.Value {
+	return slog.StringValue(c.String())
+}
+
+var _ fmt.Formatter = Version{}
+
+// Format implements [fmt.Formatter].
+//
+//	%v, %s  same as [Version.String], e.g. v1.2.3-rc.1+build
+//	%q      double-quoted %s
+//	%#v     Go syntax, e.g. exver.MustParse("v1.2.3-rc.1+build")
+//
+// Width and other flags are applied as for strings.
+// Use [Version.Short] for the form without build-meta.
+func (v Version) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprintf(f, "exver.MustParse(%q)", v.String())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), v.String())
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(exver.Version=%s)", verb, v.String())
+	}
+}
@@ -0,0 +1,110 @@
+package exver
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	var (
+		v  Version
+		c  Core
+		cs Constraint
+		g  GoVersion
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.Var(Flag(&v), "version", "")
+	fs.Var(Flag(&c), "core", "")
+	fs.Var(Flag(&cs), "constraint", "")
+	fs.Var(Flag(&g), "go", "")
+
+	err := fs.Parse([]string{
+		"-version", "v1.2.3-rc.1+build",
+		"-core", "1.2",
+		"-constraint", "^1.2",
+		"-go", "go1.22rc1",
+	})
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if v != MustParse("v1.2.3-rc.1+build") {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "v1.2.3-rc.1+build", v)
+	}
+	if c != MustParseCore("1.2") {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "1.2", c)
+	}
+	if cs.String() != "^1.2" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "^1.2", cs)
+	}
+	if g != MustParseGoVersion("go1.22rc1") {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "go1.22rc1", g)
+	}
+
+	got := fs.Lookup("version").Value.(flag.Getter).Get()
+	if got != v {
+		t.Errorf("not equal:\nexpected = %s\nactual = %v", v, got)
+	}
+	if s := fs.Lookup("core").Value.String(); s != "1.2" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "1.2", s)
+	}
+
+	if err := fs.Parse([]string{"-version", "1.2-rc.1"}); err == nil {
+		t.Errorf("should be non-nil error but nil")
+	}
+
+	// PrintDefaults must not panic on the zero value.
+	fs.PrintDefaults()
+}
+
+func TestVersion_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info(
+		"msg",
+		slog.Any("version", MustParse("v1.2.3+build")),
+		slog.Any("core", MustParseCore("1.2")),
+		slog.Any("constraint", MustParseConstraint(">=1.2 <2")),
+	)
+	expected := `{"level":"INFO","msg":"msg","version":"v1.2.3+build","core":"1.2","constraint":">=1.2 <2"}`
+	if actual := strings.TrimSpace(buf.String()); actual != expected {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", expected, actual)
+	}
+}
+
+func TestVersion_Format(t *testing.T) {
+	v := MustParse("v1.2.3-rc.1+build")
+	for _, tc := range []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "v1.2.3-rc.1+build"},
+		{"%s", "v1.2.3-rc.1+build"},
+		{"%q", `"v1.2.3-rc.1+build"`},
+		{"%#v", `exver.MustParse("v1.2.3-rc.1+build")`},
+		{"%20v|", "   v1.2.3-rc.1+build|"},
+		{"%-20s|", "v1.2.3-rc.1+build   |"},
+		{"%d", "%!d(exver.Version=v1.2.3-rc.1+build)"},
+	} {
+		if actual := fmt.Sprintf(tc.format, v); actual != tc.expected {
+			t.Errorf("%q: not equal:\nexpected = %s\nactual = %s", tc.format, tc.expected, actual)
+		}
+	}
+	if actual := fmt.Sprint(MustParse("1.2.3+build")); actual != "1.2.3+build" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "1.2.3+build", actual)
+	}
+	if actual := v.Short(); actual != "v1.2.3-rc.1" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "v1.2.3-rc.1", actual)
+	}
+}
@@ -0,0 +1,159 @@
+package exver
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func addSeeds(f *testing.F, n int) {
+	var inputs []string
+	for _, tc := range append(append([]parseTestCase{}, tests...), extendedTests...) {
+		inputs = append(inputs, tc.in)
+	}
+	inputs = append(inputs,
+		"", "v", "1.2.3-", "1.2.3+", "1.2.3-a.", "1.2.3-.a", "1.2.3-01", "1.2.3-0a", "1.2.3+01",
+		"1.2.3-a-", "1.2.3--", "1.2.3-a0", "1.2.3-alpha.0", "v1.2.3.4-rc.1+build.001",
+	)
+	for i := range inputs {
+		args := make([]any, n)
+		for j := range n {
+			args[j] = inputs[(i+j*7)%len(inputs)]
+		}
+		f.Add(args...)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	addSeeds(f, 1)
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("%q: should be *ParseError but is %#v", s, err)
+			}
+			if parseErr.Offset < 0 || parseErr.Offset > len(s) {
+				t.Fatalf("%q: offset out of range: %v", s, err)
+			}
+			return
+		}
+		if v.String() != s {
+			t.Fatalf("not round-tripping:\nexpected = %s\nactual = %s", s, v.String())
+		}
+		reparsed, err := Parse(v.String())
+		if err != nil || reparsed != v {
+			t.Fatalf("%q: re-parsing: %v, %#v", s, err, reparsed)
+		}
+		for _, ident := range v.PreReleaseIdents() {
+			if _, err := ParseIdent(ident.String()); err != nil {
+				t.Fatalf("%q: invalid pre-release identifier: %v", s, err)
+			}
+			if ident.IsNumeric() && len(ident.String()) > 1 && ident.String()[0] == '0' {
+				t.Fatalf("%q: numeric identifier with leading zero", s)
+			}
+		}
+		if v.Compare(v) != 0 {
+			t.Fatalf("%q: not equal to itself", s)
+		}
+	})
+}
+
+func FuzzCompare(f *testing.F) {
+	addSeeds(f, 3)
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		va, errA := Parse(a)
+		vb, errB := Parse(b)
+		vc, errC := Parse(c)
+		if errA != nil || errB != nil || errC != nil {
+			return
+		}
+
+		ab, ba := va.Compare(vb), vb.Compare(va)
+		if ab != -ba {
+			t.Fatalf("not antisymmetric: Compare(%q, %q) = %d, Compare(%q, %q) = %d", a, b, ab, b, a, ba)
+		}
+		if sameVersion := ignoreMeta(va.WithV(false)) == ignoreMeta(vb.WithV(false)); (ab == 0) != sameVersion {
+			t.Fatalf("Compare(%q, %q) = %d but same version = %t", a, b, ab, sameVersion)
+		}
+		bc, ac := vb.Compare(vc), va.Compare(vc)
+		if ab <= 0 && bc <= 0 && ac > 0 {
+			t.Fatalf("not transitive: %q <= %q <= %q but Compare(%q, %q) = %d", a, b, c, a, c, ac)
+		}
+		if ab >= 0 && bc >= 0 && ac < 0 {
+			t.Fatalf("not transitive: %q >= %q >= %q but Compare(%q, %q) = %d", a, b, c, a, c, ac)
+		}
+
+		ka, errA := va.SortKey()
+		kb, errB := vb.SortKey()
+		if errA == nil && errB == nil {
+			if kc := strings.Compare(ka, kb); kc != ab {
+				t.Fatalf("SortKey of %q and %q compares %d but Compare = %d", a, b, kc, ab)
+			}
+		}
+	})
+}
+
+func FuzzCoreInt64(f *testing.F) {
+	f.Add("1.2.3", "1.2.3.4")
+	f.Add("1", "1.0.0.0")
+	f.Add("9999.9999.9999.9999", "0")
+	f.Add("0.0.1", "0.1")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		ca, errA := ParseCore(a)
+		cb, errB := ParseCore(b)
+		if errA != nil || errB != nil {
+			return
+		}
+		if c, i := ca.Compare(cb), cmp.Compare(ca.Int64(), cb.Int64()); c != i {
+			t.Fatalf("Compare(%q, %q) = %d but Int64 compares %d", a, b, c, i)
+		}
+		decoded, err := CoreFromInt64(ca.Int64())
+		if err != nil || decoded.Compare(ca) != 0 {
+			t.Fatalf("%q: decoding Int64: %v, %s", a, err, decoded)
+		}
+	})
+}
+
+func FuzzPreReleaseSortableV2(f *testing.F) {
+	addSeeds(f, 2)
+	for _, pair := range [][2]string{
+		{"1.2.3-a", "1.2.3-a0"},
+		{"1.2.3-a", "1.2.3-a-"},
+		{"1.2.3-a", "1.2.3-a.0"},
+		{"1.2.3--", "1.2.3-0"},
+		{"1.2.3-" + strings.Repeat("9", 31), "1.2.3--"},
+		{"1.2.3-1.2.3.4.5.6.7.8", "1.2.3-1.2.3.4.5.6.7"},
+	} {
+		f.Add(pair[0], pair[1])
+	}
+	f.Fuzz(func(t *testing.T, a, b string) {
+		va, errA := Parse(a)
+		vb, errB := Parse(b)
+		if errA != nil || errB != nil || va.core.length != vb.core.length {
+			return
+		}
+		for _, v := range [...]Version{va, vb} {
+			idents := v.PreReleaseIdents()
+			if len(idents) > 8 {
+				return
+			}
+			for _, ident := range idents {
+				if len(ident.String()) > 31 {
+					return
+				}
+			}
+		}
+		sa, sb := va.PreReleaseSortableV2(), vb.PreReleaseSortableV2()
+		if len(sa) != 256 || len(sb) != 256 {
+			t.Fatalf("wrong length: %d, %d", len(sa), len(sb))
+		}
+		l := fmt.Sprintf("%016d_%s", va.Core().Int64(), sa)
+		r := fmt.Sprintf("%016d_%s", vb.Core().Int64(), sb)
+		if c, s := va.Compare(vb), strings.Compare(l, r); c != s {
+			t.Fatalf("Compare(%q, %q) = %d but sortable compares %d\nl = %s\nr = %s", a, b, c, s, l, r)
+		}
+	})
+}
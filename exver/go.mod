@@ -1,3 +1,5 @@
 module github.com/ngicks/go-common/exver
 
 go 1.24.0
+
+require golang.org/x/mod v0.30.0
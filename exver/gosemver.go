@@ -0,0 +1,209 @@
+package exver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GoSemver returns v in the canonical form of Go's semver, which Go modules use as versions,
+// e.g. "v1.2.0" for 1.2 and "v1.2.3-rc.1" for 1.2.3-rc.1+meta.
+// Missing components are filled with 0, v-prefix is added and build-meta is dropped,
+// as [golang.org/x/mod/semver.Canonical] does.
+//
+// It returns an error if v has 4 components, which Go's semver does not allow.
+func (v Version) GoSemver() (string, error) {
+	if v.core.length == 4 {
+		return "", fmt.Errorf("4-component version %q cannot be Go semver", v.String())
+	}
+	v.vPrefix = true
+	v.core = v.core.Normalize()
+	v.build = ""
+	return v.String(), nil
+}
+
+// IsGoCanonical reports whether v is a valid Go semver in its canonical form:
+// v-prefixed, having exactly 3 components and no build-meta.
+// Every Go module version other than "+incompatible" ones is canonical.
+func (v Version) IsGoCanonical() bool {
+	return v.vPrefix && v.core.length == 3 && v.build == ""
+}
+
+// ParseGoSemver parses s as Go's semver.
+// In addition to rules of [Parse], s must be v-prefixed and must not have the EXTRA component.
+// Like Go's semver, shorthands like "v1" and "v1.2" are accepted.
+// It is same as parsing s with [ParseOptions] of the [GoSemVer] profile.
+func ParseGoSemver(s string) (Version, error) {
+	return ParseOptions{Profile: GoSemVer}.Parse(s)
+}
+
+const pseudoVersionTimeFormat = "20060102150405"
+
+// PseudoVersion is a Go module pseudo-version,
+// which refers to a revision not tagged with a semantic version.
+//
+// A pseudo-version takes one of the following forms,
+// where yyyymmddhhmmss is the UTC commit time and abcdefabcdef is a commit hash prefix:
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef      when no base version is known
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef when the base version is vX.Y.Z-pre
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef when the base version is vX.Y.Z
+//
+// The latter two may be followed by "+incompatible" when the base version is;
+// the first one never has build-meta since it has no base version to be incompatible.
+//
+// See https://go.dev/ref/mod#pseudo-versions.
+type PseudoVersion struct {
+	v    Version
+	base Version
+	t    time.Time
+	rev  string
+}
+
+// ParsePseudoVersion parses s as a Go module pseudo-version.
+func ParsePseudoVersion(s string) (PseudoVersion, error) {
+	v, err := ParseGoSemver(s)
+	if err != nil {
+		return PseudoVersion{}, err
+	}
+	if v.core.length != 3 {
+		return PseudoVersion{}, fmt.Errorf("pseudo-version must have 3 components")
+	}
+
+	pre := v.prerelease
+	i := strings.LastIndexByte(pre, '-')
+	if i < 0 {
+		return PseudoVersion{}, fmt.Errorf("missing revision")
+	}
+	rest, rev := pre[:i], pre[i+1:]
+	if rev == "" || len(rest) < len(pseudoVersionTimeFormat) {
+		return PseudoVersion{}, fmt.Errorf("missing timestamp or revision")
+	}
+	if !isRevision(rev) {
+		return PseudoVersion{}, fmt.Errorf("invalid revision %q", rev)
+	}
+	ts := rest[len(rest)-len(pseudoVersionTimeFormat):]
+	prefix := rest[:len(rest)-len(ts)]
+	if !isNum(ts) {
+		return PseudoVersion{}, fmt.Errorf("invalid timestamp %q", ts)
+	}
+	t, err := time.Parse(pseudoVersionTimeFormat, ts)
+	if err != nil {
+		return PseudoVersion{}, fmt.Errorf("invalid timestamp %q: %w", ts, err)
+	}
+
+	base := Version{vPrefix: true, core: v.core, build: v.build}
+	switch {
+	case prefix == "":
+		if v.core.Minor() != 0 || v.core.Patch() != 0 {
+			return PseudoVersion{}, fmt.Errorf("pseudo-version without base must be vX.0.0")
+		}
+		if v.build != "" {
+			return PseudoVersion{}, fmt.Errorf("pseudo-version without base has build-meta %q", v.build)
+		}
+		base = Version{}
+	case prefix == "0.":
+		if v.core.Patch() == 0 {
+			return PseudoVersion{}, fmt.Errorf("pseudo-version based on release must have non-zero patch")
+		}
+		base.core.component[2]--
+	case strings.HasSuffix(prefix, ".0.") && len(prefix) > len(".0."):
+		base.prerelease = prefix[:len(prefix)-len(".0.")]
+	default:
+		return PseudoVersion{}, fmt.Errorf("invalid pseudo-version pre-release %q", pre)
+	}
+
+	return PseudoVersion{v: v, base: base, t: t, rev: rev}, nil
+}
+
+// NewPseudoVersion returns a pseudo-version for the revision rev committed at t,
+// following base, a version tagged on an ancestor of the revision.
+// If base is the zero Version, the pseudo-version is vMAJOR.0.0-yyyymmddhhmmss-rev.
+// Build-meta of base is dropped unless it is "+incompatible".
+// rev is usually the first 12 characters of a commit hash.
+func NewPseudoVersion(major uint16, base Version, t time.Time, rev string) (PseudoVersion, error) {
+	if rev == "" {
+		return PseudoVersion{}, fmt.Errorf("empty revision")
+	}
+	if !isRevision(rev) {
+		return PseudoVersion{}, fmt.Errorf("invalid revision %q", rev)
+	}
+	segment := t.UTC().Format(pseudoVersionTimeFormat) + "-" + rev
+
+	var s string
+	if base == (Version{}) {
+		s = fmt.Sprintf("v%d.0.0-%s", major, segment)
+	} else {
+		if base.core.length == 4 {
+			return PseudoVersion{}, fmt.Errorf("4-component base version")
+		}
+		v := base
+		v.vPrefix = true
+		v.core = v.core.Normalize()
+		// Go tooling carries only +incompatible over from the base.
+		if v.build != "incompatible" {
+			v.build = ""
+		}
+		if v.prerelease != "" {
+			v.prerelease += ".0." + segment
+		} else {
+			core, err := v.core.BumpPatch()
+			if err != nil {
+				return PseudoVersion{}, err
+			}
+			v.core = core
+			v.prerelease = "0." + segment
+		}
+		s = v.String()
+	}
+	return ParsePseudoVersion(s)
+}
+
+// Version returns p as Version.
+func (p PseudoVersion) Version() Version {
+	return p.v
+}
+
+// Base returns the version p is based on, as [golang.org/x/mod/module.PseudoVersionBase] does.
+// ok is false if p has no base version, i.e. p is in the form of vX.0.0-yyyymmddhhmmss-abcdefabcdef.
+func (p PseudoVersion) Base() (base Version, ok bool) {
+	return p.base, p.base != (Version{})
+}
+
+// Time returns the commit time encoded in p, in UTC.
+func (p PseudoVersion) Time() time.Time {
+	return p.t
+}
+
+// Rev returns the revision identifier encoded in p.
+func (p PseudoVersion) Rev() string {
+	return p.rev
+}
+
+func (p PseudoVersion) String() string {
+	return p.v.String()
+}
+
+// Compare compares p and u like [golang.org/x/mod/semver.Compare].
+func (p PseudoVersion) Compare(u PseudoVersion) int {
+	return p.v.Compare(u.v)
+}
+
+// isRevision reports whether rev consists only of ASCII letters and digits.
+func isRevision(rev string) bool {
+	for _, r := range rev {
+		if !('0' <= r && r <= '9') && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPseudo reports whether v is a Go module pseudo-version.
+func (v Version) IsPseudo() bool {
+	if !v.vPrefix || v.core.length != 3 || v.prerelease == "" {
+		return false
+	}
+	_, err := ParsePseudoVersion(v.String())
+	return err == nil
+}
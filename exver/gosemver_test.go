@@ -0,0 +1,318 @@
+package exver
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+func TestGoSemver(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{"1", "v1.0.0"},
+		{"v1.2", "v1.2.0"},
+		{"1.2.3", "v1.2.3"},
+		{"v1.2.3-rc.1+meta", "v1.2.3-rc.1"},
+		{"v1.2.3+incompatible", "v1.2.3"},
+	} {
+		v := MustParse(tc.input)
+		s, err := v.GoSemver()
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.input, err)
+			continue
+		}
+		if s != tc.expected {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.expected, s)
+		}
+		if goCanonical := semver.Canonical("v" + strings.TrimPrefix(tc.input, "v")); goCanonical != s {
+			t.Errorf("%q: not consistent with semver.Canonical:\nexpected = %s\nactual = %s", tc.input, goCanonical, s)
+		}
+	}
+
+	if _, err := MustParse("v1.2.3.4").GoSemver(); err == nil {
+		t.Errorf("%q: should be non-nil error but nil", "v1.2.3.4")
+	}
+}
+
+func TestIsGoCanonical(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected bool
+	}{
+		{"v1.2.3", true},
+		{"v1.2.3-pre.1", true},
+		{"v0.0.0-20240102030405-abcdefabcdef", true},
+		{"1.2.3", false},
+		{"v1.2", false},
+		{"v1.2.3+incompatible", false},
+		{"v1.2.3.4", false},
+	} {
+		if actual := MustParse(tc.input).IsGoCanonical(); actual != tc.expected {
+			t.Errorf("%q: expected %t but %t", tc.input, tc.expected, actual)
+		}
+		if semver.IsValid(tc.input) {
+			if goCanonical := semver.Canonical(tc.input) == tc.input; goCanonical != tc.expected {
+				t.Errorf("%q: not consistent with semver.Canonical", tc.input)
+			}
+		}
+	}
+}
+
+func TestParseGoSemver(t *testing.T) {
+	for _, input := range []string{"v1", "v1.2", "v1.2.3", "v1.2.3-rc.1+meta"} {
+		if _, err := ParseGoSemver(input); err != nil {
+			t.Errorf("%q: should be nil error but is %v", input, err)
+		}
+		if !semver.IsValid(input) {
+			t.Errorf("%q: is not valid for semver", input)
+		}
+	}
+	for _, input := range []string{"1.2.3", "v1.2.3.4", "v01.2.3", "v1.2.3-", "v1.2.3-01"} {
+		if _, err := ParseGoSemver(input); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", input)
+		}
+	}
+}
+
+func TestGoSemver_compare(t *testing.T) {
+	versions := []string{
+		"v0.0.0-20230102030405-abcdefabcdef",
+		"v0.0.0-20240102030405-abcdefabcdef",
+		"v0.0.0",
+		"v0.1.0-alpha",
+		"v0.1.0-alpha.1",
+		"v0.1.0-alpha.beta",
+		"v0.1.0-beta.2",
+		"v0.1.0-beta.11",
+		"v0.1.0-rc.1",
+		"v0.1.0-rc.1.0.20240102030405-abcdefabcdef",
+		"v0.1.0",
+		"v0.1.1-0.20240102030405-abcdefabcdef",
+		"v0.1.1",
+		"v1.0.0",
+		"v1.10.0",
+		"v2.0.0+incompatible",
+	}
+	for _, l := range versions {
+		for _, r := range versions {
+			expected := semver.Compare(l, r)
+			actual := MustParse(l).Compare(MustParse(r))
+			if expected != actual {
+				t.Errorf("Compare(%q, %q): not equal:\nexpected = %d\nactual = %d", l, r, expected, actual)
+			}
+		}
+	}
+}
+
+func TestParsePseudoVersion(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		base  string
+		time  time.Time
+		rev   string
+	}{
+		{
+			"v0.0.0-20240102030405-abcdefabcdef",
+			"",
+			time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			"abcdefabcdef",
+		},
+		{
+			"v2.0.1-0.20240102030405-abcdefabcdef+incompatible",
+			"v2.0.0+incompatible",
+			time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			"abcdefabcdef",
+		},
+		{
+			"v1.2.4-0.20240102030405-abcdefabcdef",
+			"v1.2.3",
+			time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			"abcdefabcdef",
+		},
+		{
+			"v1.2.3-rc.1.0.20240102030405-abcdefabcdef",
+			"v1.2.3-rc.1",
+			time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			"abcdefabcdef",
+		},
+		{
+			"v1.2.3-pre.0.20240102030405-0123456789ab+incompatible",
+			"v1.2.3-pre+incompatible",
+			time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			"0123456789ab",
+		},
+	} {
+		p, err := ParsePseudoVersion(tc.input)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.input, err)
+			continue
+		}
+		if !module.IsPseudoVersion(tc.input) {
+			t.Errorf("%q: is not a pseudo-version for module", tc.input)
+		}
+		if !p.Version().IsPseudo() {
+			t.Errorf("%q: IsPseudo should be true", tc.input)
+		}
+		if p.String() != tc.input {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.input, p.String())
+		}
+		base, ok := p.Base()
+		if ok != (tc.base != "") {
+			t.Errorf("%q: Base returned ok = %t", tc.input, ok)
+		}
+		if ok && base.String() != tc.base {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.base, base.String())
+		}
+		if goBase, _ := module.PseudoVersionBase(tc.input); goBase != tc.base {
+			t.Errorf("%q: not consistent with module.PseudoVersionBase:\nexpected = %s\nactual = %s", tc.input, goBase, tc.base)
+		}
+		if !p.Time().Equal(tc.time) {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.time, p.Time())
+		}
+		if p.Rev() != tc.rev {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.rev, p.Rev())
+		}
+	}
+
+	for _, input := range []string{
+		"v1.2.3",
+		"v1.2.3-pre",
+		"1.2.3-0.20240102030405-abcdefabcdef",
+		"v1.2.0-0.20240102030405-abcdefabcdef",
+		"v1.2.0-20240102030405-abcdefabcdef",
+		"v1.2.3-0.2024010203040-abcdefabcdef",
+		"v1.2.3-0.20241302030405-abcdefabcdef",
+		"v1.2.3-0.20240102030405-",
+		"v1.2.3-0.20240102030405",
+		"v1.2.3.4-0.20240102030405-abcdefabcdef",
+		"v1.2.3-pre.20240102030405-abcdefabcdef",
+		"v0.0.0-20240101120000-abc.def",
+		"v2.0.0-20240101120000-abcdef123456+incompatible",
+	} {
+		if _, err := ParsePseudoVersion(input); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", input)
+		}
+		if v, err := Parse(input); err == nil && v.IsPseudo() {
+			t.Errorf("%q: IsPseudo should be false", input)
+		}
+	}
+
+	// module.IsPseudoVersion checks only syntax; it does not reject the semantic errors above.
+	for _, input := range []string{
+		"v1.2.3-0.20240102030405-",
+		"v0.0.0-20240101120000-abc.def",
+	} {
+		if module.IsPseudoVersion(input) {
+			t.Errorf("%q: not consistent with module.IsPseudoVersion", input)
+		}
+	}
+	for _, input := range []string{
+		"v1.2.0-0.20240102030405-abcdefabcdef",
+		"v2.0.0-20240101120000-abcdef123456+incompatible",
+	} {
+		if _, err := module.PseudoVersionBase(input); err == nil {
+			t.Errorf("%q: not consistent with module.PseudoVersionBase", input)
+		}
+	}
+}
+
+func TestNewPseudoVersion(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 12, 4, 5, 0, time.FixedZone("", 9*60*60))
+	for _, tc := range []struct {
+		major uint16
+		base  string
+	}{
+		{0, ""},
+		{2, ""},
+		{0, "v1.2.3"},
+		{0, "1.2"},
+		{0, "v1.2.3-rc.1"},
+		{0, "v2.0.0+incompatible"},
+		{0, "v2.0.0-rc.1+incompatible"},
+		// build-meta other than +incompatible is dropped.
+		{0, "v1.2.3+meta.7"},
+		{0, "v1.2.3-rc.1+meta"},
+	} {
+		var base Version
+		if tc.base != "" {
+			base = MustParse(tc.base)
+		}
+		p, err := NewPseudoVersion(tc.major, base, ts, "abcdefabcdef")
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.base, err)
+			continue
+		}
+		var goBase string
+		if tc.base != "" {
+			goBase, _ = base.GoSemver()
+			if base.Build() == "incompatible" {
+				goBase += "+incompatible"
+			}
+		}
+		expected := module.PseudoVersion(
+			"v"+strconv.Itoa(int(tc.major)),
+			goBase,
+			ts,
+			"abcdefabcdef",
+		)
+		if p.String() != expected {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", expected, p.String())
+		}
+		if !p.Time().Equal(ts) {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", ts, p.Time())
+		}
+	}
+
+	for _, rev := range []string{"", "abc-def", "abc.def"} {
+		if _, err := NewPseudoVersion(0, Version{}, ts, rev); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", rev)
+		}
+	}
+	if _, err := NewPseudoVersion(0, MustParse("v1.2.3.4"), ts, "abcdef"); err == nil {
+		t.Errorf("should be non-nil error but nil")
+	}
+}
+
+func TestPseudoVersion_Compare(t *testing.T) {
+	ordered := []string{
+		"v0.0.0-20230102030405-abcdefabcdef",
+		"v0.0.0-20240102030405-abcdefabcdef",
+		"v1.2.3-pre.0.20240102030405-abcdefabcdef",
+		"v1.2.4-0.20230102030405-abcdefabcdef",
+		"v1.2.4-0.20240102030405-abcdefabcdef",
+	}
+	for i, l := range ordered {
+		for j, r := range ordered {
+			pl, pr := must(ParsePseudoVersion(l)), must(ParsePseudoVersion(r))
+			if expected, actual := semver.Compare(l, r), pl.Compare(pr); expected != actual {
+				t.Errorf("Compare(%q, %q): not equal:\nexpected = %d\nactual = %d", l, r, expected, actual)
+			}
+			if expected, actual := compareInt(i, j), pl.Compare(pr); expected != actual {
+				t.Errorf("Compare(%q, %q): not equal:\nexpected = %d\nactual = %d", l, r, expected, actual)
+			}
+		}
+	}
+}
+
+func compareInt(i, j int) int {
+	switch {
+	case i < j:
+		return -1
+	case i > j:
+		return 1
+	}
+	return 0
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
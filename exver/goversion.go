@@ -0,0 +1,243 @@
+package exver
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GoVersion is a version of the Go toolchain or the Go language,
+// e.g. go1.21, 1.21.0, 1.22beta2 or 1.22rc1.
+//
+// Go versions are not semantic versions:
+// a version without patch is the language version and precedes its release candidates,
+// which in turn precede the first release, i.e. 1.21 < 1.21rc1 < 1.21.0.
+// [GoVersion.Compare] follows the ordering of the Go toolchain.
+//
+// GoVersion remembers its spelling: [GoVersion.String] returns the text given to [ParseGoVersion].
+//
+// See https://go.dev/doc/toolchain#version.
+type GoVersion struct {
+	goPrefix bool
+	core     Core
+	kind     string // "", "beta" or "rc"
+	pre      uint16
+	preNum   bool // whether the number after kind is spelled, e.g. false for go1.21rc
+}
+
+// ParseGoVersion parses s as a Go version.
+//
+// The accepted form is
+//
+//	[go]MAJOR[.MINOR[.PATCH]]
+//	[go]MAJOR.MINOR(beta|rc)[N]
+//
+// Each number must not have leading zeros and is limited at maximum of 9999.
+// As in go/version, the number after beta or rc may be omitted, e.g. go1.21rc,
+// which precedes any numbered one of the same kind.
+func ParseGoVersion(s string) (GoVersion, error) {
+	var v GoVersion
+	rest, ok := strings.CutPrefix(s, "go")
+	v.goPrefix = ok
+
+	var nums []uint16
+	for i := range 3 {
+		if i > 0 {
+			if len(rest) == 0 || rest[0] != '.' {
+				break
+			}
+			rest = rest[1:]
+		}
+		num, err := goVersionNum(&rest, componentName(i))
+		if err != nil {
+			return GoVersion{}, err
+		}
+		nums = append(nums, num)
+	}
+	core, err := NewCore(nums)
+	if err != nil {
+		return GoVersion{}, err
+	}
+	v.core = core
+
+	if len(rest) == 0 {
+		return v, nil
+	}
+
+	for _, kind := range [...]string{"beta", "rc"} {
+		if after, ok := strings.CutPrefix(rest, kind); ok {
+			v.kind, rest = kind, after
+			break
+		}
+	}
+	switch {
+	case v.kind == "":
+		return GoVersion{}, fmt.Errorf("extra string %q after go version", rest)
+	case core.length != 2:
+		return GoVersion{}, fmt.Errorf("%q is only allowed right after minor", v.kind)
+	case len(rest) == 0:
+		return v, nil
+	}
+	pre, err := goVersionNum(&rest, v.kind)
+	if err != nil {
+		return GoVersion{}, err
+	}
+	if len(rest) != 0 {
+		return GoVersion{}, fmt.Errorf("extra string %q after go version", rest)
+	}
+	v.pre, v.preNum = pre, true
+	return v, nil
+}
+
+func goVersionNum(s *string, name string) (uint16, error) {
+	num, rest, ok := numericIdentifier(*s)
+	if !ok {
+		return 0, fmt.Errorf("missing %q", name)
+	}
+	if len(rest) > 0 && digit(rune(rest[0])) {
+		return 0, fmt.Errorf("%q has leading zero", name)
+	}
+	parsed, err := strconv.ParseUint(num, 10, 16)
+	if err != nil || parsed > componentMax {
+		return 0, fmt.Errorf("%q is greater than %d", name, componentMax)
+	}
+	*s = rest
+	return uint16(parsed), nil
+}
+
+// MustParseGoVersion is like [ParseGoVersion] but panics when s is not a valid Go version.
+func MustParseGoVersion(s string) GoVersion {
+	v, err := ParseGoVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// GoPrefix returns true if v is prefixed with `go`.
+func (v GoVersion) GoPrefix() bool {
+	return v.goPrefix
+}
+
+// Core returns numeric components of v.
+// Its length is the number of components spelled in the text.
+func (v GoVersion) Core() Core {
+	return v.core
+}
+
+// PreRelease returns the pre-release kind ("beta" or "rc") and its number.
+// kind is empty if v is not a pre-release.
+// n is 0 if the number is omitted, e.g. go1.21rc; use [GoVersion.String] to tell it from go1.21rc0.
+func (v GoVersion) PreRelease() (kind string, n uint16) {
+	return v.kind, v.pre
+}
+
+// Lang returns the language version of v, i.e. v without patch and pre-release.
+// For example, Lang of go1.21rc2 and go1.21.3 is go1.21.
+func (v GoVersion) Lang() GoVersion {
+	if v.core.length > 2 {
+		v.core.component[2] = 0
+		v.core.length = 2
+	}
+	v.kind, v.pre, v.preNum = "", 0, false
+	return v
+}
+
+func (v GoVersion) String() string {
+	var builder strings.Builder
+	if v.goPrefix {
+		builder.WriteString("go")
+	}
+	v.core.write(&builder)
+	if v.kind != "" {
+		builder.WriteString(v.kind)
+		if v.preNum {
+			builder.WriteString(strconv.FormatUint(uint64(v.pre), 10))
+		}
+	}
+	return builder.String()
+}
+
+// Compare returns
+//
+//	-1 if v is less than u,
+//	 0 if v equals u,
+//	+1 if v is greater than u.
+//
+// The go prefix does not affect ordering.
+// A pre-release without number sorts before numbered ones, e.g. 1.21rc < 1.21rc0 < 1.21rc1.
+// A missing patch sorts before any pre-release and release of the same minor, e.g. 1.21 < 1.21rc1 < 1.21.0,
+// except for versions before 1.21 where a missing patch means 0, e.g. 1.20 equals 1.20.0.
+// A missing minor always means 0.
+func (v GoVersion) Compare(u GoVersion) int {
+	for i := range 2 {
+		if c := cmp.Compare(v.core.component[i], u.core.component[i]); c != 0 {
+			return c
+		}
+	}
+	if c := cmp.Compare(v.patch(), u.patch()); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.kind, u.kind); c != 0 {
+		return c
+	}
+	// An omitted number precedes any number, as go/version does.
+	if v.preNum != u.preNum {
+		if v.preNum {
+			return +1
+		}
+		return -1
+	}
+	return cmp.Compare(v.pre, u.pre)
+}
+
+// patch returns patch for comparison, where -1 means missing.
+func (v GoVersion) patch() int {
+	switch {
+	case v.core.length >= 3:
+		return int(v.core.Patch())
+	case v.core.length == 1:
+		return 0
+	case v.kind == "" && v.core.Minor() < 21:
+		return 0
+	}
+	return -1
+}
+
+var (
+	_ encoding.TextMarshaler   = GoVersion{}
+	_ encoding.TextUnmarshaler = (*GoVersion)(nil)
+)
+
+func (v GoVersion) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *GoVersion) UnmarshalText(text []byte) error {
+	parsed, err := ParseGoVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+var (
+	_ json.Marshaler   = GoVersion{}
+	_ json.Unmarshaler = (*GoVersion)(nil)
+)
+
+func (v GoVersion) MarshalJSON() ([]byte, error) {
+	// No need to escape: only numeric tokens and a few letters are permitted.
+	return []byte("\"" + v.String() + "\""), nil
+}
+
+func (v *GoVersion) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 {
+		return fmt.Errorf("too short")
+	}
+	return v.UnmarshalText(data[1 : len(data)-1])
+}
@@ -0,0 +1,156 @@
+package exver
+
+import (
+	"encoding/json"
+	goversion "go/version"
+	"strings"
+	"testing"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		core  string
+		kind  string
+		pre   uint16
+	}{
+		{"go1", "1", "", 0},
+		{"1.21", "1.21", "", 0},
+		{"go1.21", "1.21", "", 0},
+		{"1.21.0", "1.21.0", "", 0},
+		{"go1.21.13", "1.21.13", "", 0},
+		{"1.22beta2", "1.22", "beta", 2},
+		{"go1.22rc1", "1.22", "rc", 1},
+		{"go1.21rc", "1.21", "rc", 0},
+		{"1.22beta", "1.22", "beta", 0},
+		{"go1.9999.9999", "1.9999.9999", "", 0},
+	} {
+		v, err := ParseGoVersion(tc.input)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.input, err)
+			continue
+		}
+		if v.String() != tc.input {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.input, v.String())
+		}
+		if v.GoPrefix() != strings.HasPrefix(tc.input, "go") {
+			t.Errorf("%q: wrong GoPrefix", tc.input)
+		}
+		if v.Core() != MustParseCore(tc.core) {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.core, v.Core())
+		}
+		if kind, pre := v.PreRelease(); kind != tc.kind || pre != tc.pre {
+			t.Errorf("not equal:\nexpected = %s%d\nactual = %s%d", tc.kind, tc.pre, kind, pre)
+		}
+	}
+
+	for _, input := range []string{
+		"",
+		"go",
+		"v1.21",
+		"go1.",
+		"go1.21.",
+		"go1.021",
+		"go01.21",
+		"go1.21.00",
+		"go1.21.0.1",
+		"go1.21rc01",
+		"go1rc",
+		"go1.21.0rc",
+		"go1.21alpha1",
+		"go1rc1",
+		"go1.21.0rc1",
+		"go1.21rc1.1",
+		"go1.21-rc.1",
+		"go1.10000",
+		"go 1.21",
+	} {
+		if _, err := ParseGoVersion(input); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", input)
+		}
+	}
+}
+
+func TestGoVersion_Compare(t *testing.T) {
+	ordered := [][]string{
+		{"1", "go1.0", "1.0.0"},
+		{"1.1"},
+		{"go1.20beta1"},
+		{"go1.20rc1"},
+		{"1.20", "1.20.0"},
+		{"1.20.1"},
+		{"1.21"},
+		{"1.21rc"},
+		{"1.21rc0"},
+		{"1.21rc1"},
+		{"1.21rc2"},
+		{"1.21.0"},
+		{"1.21.1"},
+		{"go1.21.10"},
+		{"go1.22beta"},
+		{"go1.22beta1"},
+		{"go1.22beta2"},
+		{"go1.22rc1"},
+		{"go1.22.0"},
+		{"go2"},
+	}
+	for i, li := range ordered {
+		for j, ri := range ordered {
+			for _, l := range li {
+				for _, r := range ri {
+					lv, rv := MustParseGoVersion(l), MustParseGoVersion(r)
+					actual := lv.Compare(rv)
+					if expected := compareInt(i, j); actual != expected {
+						t.Errorf("Compare(%q, %q): not equal:\nexpected = %d\nactual = %d", l, r, expected, actual)
+					}
+					goCmp := goversion.Compare("go"+strings.TrimPrefix(l, "go"), "go"+strings.TrimPrefix(r, "go"))
+					if actual != goCmp {
+						t.Errorf("Compare(%q, %q): not consistent with go/version:\nexpected = %d\nactual = %d", l, r, goCmp, actual)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGoVersion_Lang(t *testing.T) {
+	for _, tc := range [][2]string{
+		{"go1", "go1"},
+		{"go1.21", "go1.21"},
+		{"go1.21rc2", "go1.21"},
+		{"go1.21rc", "go1.21"},
+		{"1.21.3", "1.21"},
+	} {
+		lang := MustParseGoVersion(tc[0]).Lang()
+		if lang.String() != tc[1] {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc[1], lang.String())
+		}
+		if goLang := goversion.Lang("go" + strings.TrimPrefix(tc[0], "go")); goLang != "go"+strings.TrimPrefix(tc[1], "go") {
+			t.Errorf("%q: not consistent with go/version: %s", tc[0], goLang)
+		}
+	}
+}
+
+func TestGoVersion_json(t *testing.T) {
+	type sample struct {
+		V GoVersion
+	}
+	input := `{"V":"go1.22rc1"}`
+	var s sample
+	if err := json.Unmarshal([]byte(input), &s); err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if s.V != MustParseGoVersion("go1.22rc1") {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "go1.22rc1", s.V)
+	}
+	bin, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if string(bin) != input {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", input, bin)
+	}
+	if err := json.Unmarshal([]byte(`{"V":"go1.22.0rc1"}`), &s); err == nil {
+		t.Errorf("should be non-nil error but nil")
+	}
+}
@@ -0,0 +1,222 @@
+package exver
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ident is a dot-separated identifier of pre-release.
+//
+// As per the spec, an identifier is either numeric, consisting only of digits without leading zeros,
+// or alphanumeric, containing at least one non-digit.
+// The zero value is invalid.
+type Ident struct {
+	value   string
+	numeric bool
+}
+
+// ParseIdent parses s as a pre-release identifier.
+func ParseIdent(s string) (Ident, error) {
+	if !isWhole(s, preReleaseIdentifier) {
+		return Ident{}, fmt.Errorf("%q is not a valid pre-release identifier", s)
+	}
+	return Ident{value: s, numeric: isNum(s)}, nil
+}
+
+// MustParseIdent is like [ParseIdent] but panics if s is not a valid identifier.
+func MustParseIdent(s string) Ident {
+	i, err := ParseIdent(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// NumericIdent returns the numeric identifier of n.
+func NumericIdent(n uint64) Ident {
+	return Ident{value: strconv.FormatUint(n, 10), numeric: true}
+}
+
+// IsNumeric reports whether i is a numeric identifier.
+func (i Ident) IsNumeric() bool {
+	return i.numeric
+}
+
+// Uint64 returns the value of numeric identifier.
+// ok is false if i is alphanumeric or its value overflows uint64.
+func (i Ident) Uint64() (n uint64, ok bool) {
+	if !i.numeric {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(i.value, 10, 64)
+	return n, err == nil
+}
+
+func (i Ident) String() string {
+	return i.value
+}
+
+// Compare compares i and j by precedence defined in the spec:
+// numeric identifiers are compared numerically and precede alphanumeric ones,
+// which are compared lexically in ASCII sort order.
+func (i Ident) Compare(j Ident) int {
+	switch {
+	case i.numeric && !j.numeric:
+		return -1
+	case !i.numeric && j.numeric:
+		return +1
+	case i.numeric:
+		if c := cmp.Compare(len(i.value), len(j.value)); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(i.value, j.value)
+}
+
+// isWhole reports whether s as a whole is accepted by grammar.
+func isWhole(s string, grammar func(s string) (ident, rest string, ok bool)) bool {
+	ident, rest, ok := grammar(s)
+	return ok && rest == "" && ident == s
+}
+
+// PreReleaseIdents returns dot-separated identifiers of the pre-release.
+// It returns nil if v has no pre-release.
+func (v Version) PreReleaseIdents() []Ident {
+	if v.prerelease == "" {
+		return nil
+	}
+	var idents []Ident
+	for s := range strings.SplitSeq(v.prerelease, ".") {
+		idents = append(idents, Ident{value: s, numeric: isNum(s)})
+	}
+	return idents
+}
+
+// WithPreReleaseIdents returns v with the pre-release replaced by idents joined with '.'.
+// If idents is empty, the returned Version has no pre-release.
+// If any of idents is invalid, or the core of v has fewer than 3 components, it returns unmodified v and an non-nil error.
+func (v Version) WithPreReleaseIdents(idents ...Ident) (Version, error) {
+	if len(idents) == 0 {
+		v.prerelease = ""
+		return v, nil
+	}
+	if err := v.checkCoreLen("pre-release"); err != nil {
+		return v, err
+	}
+	ss := make([]string, len(idents))
+	for i, ident := range idents {
+		if !isWhole(ident.value, preReleaseIdentifier) {
+			return v, fmt.Errorf("pre-release identifier at index %d is invalid", i)
+		}
+		ss[i] = ident.value
+	}
+	return v.WithPreRelease(strings.Join(ss, "."))
+}
+
+// BuildIdents returns dot-separated identifiers of the build-meta.
+// It returns nil if v has no build-meta.
+func (v Version) BuildIdents() []string {
+	if v.build == "" {
+		return nil
+	}
+	return strings.Split(v.build, ".")
+}
+
+// AppendBuild returns v with idents appended to the build-meta.
+// If any of idents is not a valid build identifier, or the core of v has fewer than 3 components,
+// it returns unmodified v and an non-nil error.
+func (v Version) AppendBuild(idents ...string) (Version, error) {
+	for i, ident := range idents {
+		if !isWhole(ident, buildIdentidiers) {
+			return v, fmt.Errorf("build identifier %q at index %d is invalid", ident, i)
+		}
+	}
+	if len(idents) == 0 {
+		return v, nil
+	}
+	if err := v.checkCoreLen("build-meta"); err != nil {
+		return v, err
+	}
+	joined := strings.Join(idents, ".")
+	if v.build != "" {
+		joined = v.build + "." + joined
+	}
+	v.build = joined
+	return v, nil
+}
+
+const (
+	buildKeyCommit = "sha"
+	buildKeyTime   = "ts"
+)
+
+// WithBuildCommit returns v with the commit hash sha embedded in the build-meta as a "sha.<sha>" pair of identifiers,
+// e.g. 1.2.3+sha.abcdef1. An existing pair is replaced.
+// sha must be non-empty lowercase hexadecimal, and the core of v must have 3 or more components.
+func (v Version) WithBuildCommit(sha string) (Version, error) {
+	if sha == "" || strings.Trim(sha, "0123456789abcdef") != "" {
+		return v, fmt.Errorf("%q is not a lowercase hexadecimal commit hash", sha)
+	}
+	return v.withBuildPair(buildKeyCommit, sha)
+}
+
+// BuildCommit returns the commit hash embedded by [Version.WithBuildCommit].
+func (v Version) BuildCommit() (sha string, ok bool) {
+	return v.buildPair(buildKeyCommit)
+}
+
+// WithBuildTime returns v with t embedded in the build-meta as a "ts.<yyyymmddhhmmss>" pair of identifiers,
+// e.g. 1.2.3+ts.20240101120000. An existing pair is replaced.
+// t is formatted in UTC, in the same layout as timestamps in Go pseudo-versions, thus sub-second precision is lost.
+// If the core of v has fewer than 3 components, it returns unmodified v and an non-nil error.
+func (v Version) WithBuildTime(t time.Time) (Version, error) {
+	return v.withBuildPair(buildKeyTime, t.UTC().Format(pseudoVersionTimeFormat))
+}
+
+// BuildTime returns the time embedded by [Version.WithBuildTime].
+// ok is false if v has no such pair or the value is not a valid timestamp.
+func (v Version) BuildTime() (t time.Time, ok bool) {
+	s, ok := v.buildPair(buildKeyTime)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(pseudoVersionTimeFormat, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// checkCoreLen returns an error if v cannot have the part named by name,
+// since pre-release and build-meta may only follow a core with 3 or more components.
+func (v Version) checkCoreLen(name string) error {
+	if v.core.length < 3 {
+		return fmt.Errorf("%s requires 3 or more core components but %q has %d", name, v.core.String(), v.core.length)
+	}
+	return nil
+}
+
+func (v Version) buildPair(key string) (value string, ok bool) {
+	idents := v.BuildIdents()
+	for i := 0; i+1 < len(idents); i++ {
+		if idents[i] == key {
+			return idents[i+1], true
+		}
+	}
+	return "", false
+}
+
+func (v Version) withBuildPair(key, value string) (Version, error) {
+	idents := v.BuildIdents()
+	for i := 0; i+1 < len(idents); i++ {
+		if idents[i] == key {
+			idents = append(idents[:i:i], idents[i+2:]...)
+			break
+		}
+	}
+	v.build = strings.Join(idents, ".")
+	return v.AppendBuild(key, value)
+}
@@ -0,0 +1,177 @@
+package exver
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIdent(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		numeric bool
+	}{
+		{"0", true},
+		{"11", true},
+		{"rc", false},
+		{"-", false},
+		{"alpha-1", false},
+		{"1a", false},
+	} {
+		i, err := ParseIdent(tc.input)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.input, err)
+			continue
+		}
+		if i.String() != tc.input || i.IsNumeric() != tc.numeric {
+			t.Errorf("%q: wrong ident: %q, numeric = %t", tc.input, i, i.IsNumeric())
+		}
+		_, ok := i.Uint64()
+		if ok != tc.numeric {
+			t.Errorf("%q: Uint64 ok = %t", tc.input, ok)
+		}
+	}
+	for _, input := range []string{"", "a.b", "a+b", "a_b"} {
+		if _, err := ParseIdent(input); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", input)
+		}
+	}
+	if n, ok := NumericIdent(42).Uint64(); !ok || n != 42 {
+		t.Errorf("not equal:\nexpected = %d\nactual = %d", 42, n)
+	}
+
+	ordered := []Ident{NumericIdent(1), NumericIdent(2), NumericIdent(10), MustParseIdent("-"), MustParseIdent("alpha"), MustParseIdent("beta")}
+	for i := range ordered {
+		for j := range ordered {
+			if expected, actual := compareInt(i, j), ordered[i].Compare(ordered[j]); expected != actual {
+				t.Errorf("Compare(%q, %q): not equal:\nexpected = %d\nactual = %d", ordered[i], ordered[j], expected, actual)
+			}
+		}
+	}
+}
+
+func TestVersion_PreReleaseIdents(t *testing.T) {
+	v := MustParse("1.2.3-rc.3.x-1+build.01")
+	idents := v.PreReleaseIdents()
+	expected := []Ident{MustParseIdent("rc"), NumericIdent(3), MustParseIdent("x-1")}
+	if !slices.Equal(idents, expected) {
+		t.Errorf("not equal:\nexpected = %v\nactual = %v", expected, idents)
+	}
+	if idents := MustParse("1.2.3").PreReleaseIdents(); idents != nil {
+		t.Errorf("should be nil but %v", idents)
+	}
+
+	n, _ := idents[1].Uint64()
+	idents[1] = NumericIdent(n + 1)
+	bumped, err := v.WithPreReleaseIdents(idents...)
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if bumped.String() != "1.2.3-rc.4.x-1+build.01" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "1.2.3-rc.4.x-1+build.01", bumped)
+	}
+
+	cleared, err := v.WithPreReleaseIdents()
+	if err != nil || cleared.String() != "1.2.3+build.01" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s (%v)", "1.2.3+build.01", cleared, err)
+	}
+
+	if unchanged, err := v.WithPreReleaseIdents(MustParseIdent("rc"), Ident{}); err == nil || unchanged != v {
+		t.Errorf("should be non-nil error and unmodified but is %v, %s", err, unchanged)
+	}
+}
+
+func TestVersion_AppendBuild(t *testing.T) {
+	v := MustParse("1.2.3")
+	v, err := v.AppendBuild("build", "20240101")
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	v, err = v.AppendBuild("001")
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if v.String() != "1.2.3+build.20240101.001" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "1.2.3+build.20240101.001", v)
+	}
+	if expected, actual := []string{"build", "20240101", "001"}, v.BuildIdents(); !slices.Equal(expected, actual) {
+		t.Errorf("not equal:\nexpected = %v\nactual = %v", expected, actual)
+	}
+	for _, ident := range []string{"", "a.b", "a+b", "a_b"} {
+		if unchanged, err := v.AppendBuild("ok", ident); err == nil || unchanged != v {
+			t.Errorf("%q: should be non-nil error and unmodified but is %v, %s", ident, err, unchanged)
+		}
+	}
+}
+
+func TestVersion_identifiers_short_core(t *testing.T) {
+	// Pre-release and build-meta may not follow a core with fewer than 3 components.
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, input := range []string{"1", "1.2"} {
+		v := MustParse(input)
+		for _, fn := range []func(Version) (Version, error){
+			func(v Version) (Version, error) { return v.WithPreReleaseIdents(MustParseIdent("rc"), NumericIdent(1)) },
+			func(v Version) (Version, error) { return v.AppendBuild("build") },
+			func(v Version) (Version, error) { return v.WithBuildCommit("abcdef1") },
+			func(v Version) (Version, error) { return v.WithBuildTime(ts) },
+		} {
+			if out, err := fn(v); err == nil || out != v {
+				t.Errorf("%q: should be non-nil error and unmodified but is %v, %s", input, err, out)
+			}
+		}
+	}
+	// 4 components are allowed as Parse does.
+	if v, err := MustParse("1.2.3.4").AppendBuild("build"); err != nil || v.String() != "1.2.3.4+build" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s, %v", "1.2.3.4+build", v, err)
+	}
+}
+
+func TestVersion_BuildCommitTime(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 12, 4, 5, 6, time.FixedZone("", 9*60*60))
+
+	v := MustParse("v1.2.3-rc.1+dirty")
+	v, err := v.WithBuildCommit("abcdef1")
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	v, err = v.WithBuildTime(ts)
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if v.String() != "v1.2.3-rc.1+dirty.sha.abcdef1.ts.20240102030405" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "v1.2.3-rc.1+dirty.sha.abcdef1.ts.20240102030405", v)
+	}
+
+	v, err = v.WithBuildCommit("0123456")
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if v.String() != "v1.2.3-rc.1+dirty.ts.20240102030405.sha.0123456" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "v1.2.3-rc.1+dirty.ts.20240102030405.sha.0123456", v)
+	}
+
+	sha, ok := v.BuildCommit()
+	if !ok || sha != "0123456" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "0123456", sha)
+	}
+	bt, ok := v.BuildTime()
+	if !ok || !bt.Equal(ts.Truncate(time.Second)) {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", ts.Truncate(time.Second), bt)
+	}
+
+	for _, sha := range []string{"", "ABCDEF", "xyz", "abc.def"} {
+		if _, err := v.WithBuildCommit(sha); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", sha)
+		}
+	}
+
+	for _, input := range []string{"1.2.3", "1.2.3+sha", "1.2.3+ts.2024", "1.2.3+ts"} {
+		v := MustParse(input)
+		if _, ok := v.BuildCommit(); ok {
+			t.Errorf("%q: should not have commit", input)
+		}
+		if _, ok := v.BuildTime(); ok {
+			t.Errorf("%q: should not have time", input)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package exver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is returned from [Parse], [ParseCore] and [ParseOptions.Parse]
+// when the input is not accepted.
+type ParseError struct {
+	// Input is the string being parsed.
+	Input string
+	// Offset is the byte offset in Input where the error is found.
+	Offset int
+	// Component names the offending part of Input:
+	// one of "major", "minor", "patch", "extra", "pre-release" and "build-meta",
+	// or empty if the error is not specific to any of them.
+	Component string
+	// Reason describes what is wrong.
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	if e.Component == "" {
+		return fmt.Sprintf("parsing %q: offset %d: %s", e.Input, e.Offset, e.Reason)
+	}
+	return fmt.Sprintf("parsing %q: offset %d: %s: %s", e.Input, e.Offset, e.Component, e.Reason)
+}
+
+// Profile selects a set of grammar rules for [ParseOptions].
+type Profile int
+
+const (
+	// Extended accepts the extended version as [Parse] does:
+	// optional v-prefix, 1 to 4 components, and pre-release and build-meta only after patch or extra.
+	Extended Profile = iota
+	// StrictSemVer accepts only versions conforming to [Semantic Versioning 2.0]:
+	// no v-prefix and exactly 3 components.
+	//
+	// [Semantic Versioning 2.0]: https://semver.org/
+	StrictSemVer
+	// GoSemVer accepts only versions conforming to Go's semver:
+	// v-prefix is required and at most 3 components are allowed.
+	GoSemVer
+)
+
+func (p Profile) String() string {
+	switch p {
+	case Extended:
+		return "Extended"
+	case StrictSemVer:
+		return "StrictSemVer"
+	case GoSemVer:
+		return "GoSemVer"
+	default:
+		return fmt.Sprintf("Profile(%d)", int(p))
+	}
+}
+
+// ParseOptions configures [ParseOptions.Parse].
+// The zero value parses the same as [Parse].
+type ParseOptions struct {
+	Profile Profile
+	// ComponentMax limits each version component.
+	// If zero, the default maximum 9999 is used.
+	// It must not be greater than 9999.
+	ComponentMax uint16
+}
+
+// Parse parses s as a version under the rules configured by o.
+// If s is not accepted, it returns an error of type *ParseError,
+// which can be retrieved with [errors.As].
+func (o ParseOptions) Parse(s string) (Version, error) {
+	max := uint64(o.ComponentMax)
+	switch {
+	case max == 0:
+		max = componentMax
+	case max > componentMax:
+		return Version{}, fmt.Errorf("ComponentMax must not be greater than %d but is %d", componentMax, max)
+	}
+
+	v, a, b, c, d, pre_, build_, err := vPrefixedValidExtendedVer(s, max)
+	if err != nil {
+		err.(*ParseError).Input = s
+		return Version{}, err
+	}
+
+	parsed := Version{
+		vPrefix:    v,
+		core:       convertCore(a, b, c, d),
+		prerelease: pre_,
+		build:      build_,
+	}
+
+	coreEnd := strings.IndexAny(s, "-+")
+	if coreEnd < 0 {
+		coreEnd = len(s)
+	}
+	switch o.Profile {
+	case Extended:
+	case StrictSemVer:
+		if v {
+			return Version{}, &ParseError{Input: s, Reason: "v-prefix is not allowed"}
+		}
+		if parsed.core.length < 3 {
+			return Version{}, &ParseError{Input: s, Offset: coreEnd, Component: componentName(parsed.core.length), Reason: "missing"}
+		}
+	case GoSemVer:
+		if !v {
+			return Version{}, &ParseError{Input: s, Reason: "missing v-prefix"}
+		}
+	default:
+		return Version{}, fmt.Errorf("unknown profile %s", o.Profile)
+	}
+	if o.Profile != Extended && parsed.core.length == 4 {
+		return Version{}, &ParseError{
+			Input:     s,
+			Offset:    strings.LastIndexByte(s[:coreEnd], '.') + 1,
+			Component: "extra",
+			Reason:    "not allowed in " + o.Profile.String(),
+		}
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,119 @@
+package exver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseOptions(t *testing.T) {
+	for _, tc := range []struct {
+		opts     ParseOptions
+		accepted []string
+		rejected []string
+	}{
+		{
+			ParseOptions{},
+			[]string{"1", "v1.2", "1.2.3-rc.1+build", "v1.2.3.4", "9999.9999.9999.9999"},
+			[]string{"1.2.3.4.5", "10000", "v", "1.2-rc.1"},
+		},
+		{
+			ParseOptions{Profile: StrictSemVer},
+			[]string{"0.0.0", "1.2.3", "1.2.3-rc.1+build", "9999.0.0"},
+			[]string{"v1.2.3", "1", "1.2", "1.2.3.4", "1.2.3.4-rc.1", "10000.0.0"},
+		},
+		{
+			ParseOptions{Profile: GoSemVer},
+			[]string{"v1", "v1.2", "v1.2.3", "v1.2.3-rc.1+build"},
+			[]string{"1.2.3", "v1.2.3.4", "v1.2.3.4+build"},
+		},
+		{
+			ParseOptions{ComponentMax: 99},
+			[]string{"99.99.99.99", "0.0.0-100"},
+			[]string{"100", "1.100", "1.2.100", "1.2.3.100"},
+		},
+	} {
+		for _, input := range tc.accepted {
+			v, err := tc.opts.Parse(input)
+			if err != nil {
+				t.Errorf("%+v: %q: should be nil error but is %v", tc.opts, input, err)
+				continue
+			}
+			if v.String() != input {
+				t.Errorf("not equal:\nexpected = %s\nactual = %s", input, v)
+			}
+		}
+		for _, input := range tc.rejected {
+			_, err := tc.opts.Parse(input)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Errorf("%+v: %q: should be *ParseError but is %#v", tc.opts, input, err)
+			}
+		}
+	}
+
+	if _, err := (ParseOptions{ComponentMax: 10000}).Parse("1.2.3"); err == nil {
+		t.Errorf("should be non-nil error but nil")
+	}
+	if _, err := (ParseOptions{Profile: Profile(-1)}).Parse("1.2.3"); err == nil {
+		t.Errorf("should be non-nil error but nil")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		opts      ParseOptions
+		input     string
+		offset    int
+		component string
+	}{
+		{ParseOptions{}, "", 0, "major"},
+		{ParseOptions{}, "v", 1, "major"},
+		{ParseOptions{}, "1.", 2, "minor"},
+		{ParseOptions{}, "v1.x", 3, "minor"},
+		{ParseOptions{}, "1.2.3a", 5, "patch"},
+		{ParseOptions{}, "1.10000", 2, "minor"},
+		{ParseOptions{}, "v1.2.3.99999999999999999999", 7, "extra"},
+		{ParseOptions{}, "1.2.3.4.5", 7, ""},
+		{ParseOptions{}, "v1.2-rc.1", 4, "patch"},
+		{ParseOptions{}, "1.2.3-rc..1", 6, "pre-release"},
+		{ParseOptions{}, "1.2.3-rc.1+.", 11, "build-meta"},
+		{ParseOptions{}, "1.2.3-rc_1", 8, "pre-release"},
+		{ParseOptions{}, "1.2.3+b_1", 7, "build-meta"},
+		{ParseOptions{}, "01.2.3", 0, "major"},
+		{ParseOptions{}, "1.2.03", 4, "patch"},
+		{ParseOptions{}, "1.2.3-", 6, "pre-release"},
+		{ParseOptions{}, "1.2.3+", 6, "build-meta"},
+		{ParseOptions{}, "1.2.3-a.", 6, "pre-release"},
+		{ParseOptions{}, "1.2.3-a..b", 6, "pre-release"},
+		{ParseOptions{}, "1.2.3-01", 7, "pre-release"},
+		{ParseOptions{Profile: StrictSemVer}, "v1.2.3", 0, ""},
+		{ParseOptions{Profile: StrictSemVer}, "1.2", 3, "patch"},
+		{ParseOptions{Profile: StrictSemVer}, "1", 1, "minor"},
+		{ParseOptions{Profile: StrictSemVer}, "1.2.3.4-pre", 6, "extra"},
+		{ParseOptions{Profile: GoSemVer}, "1.2.3", 0, ""},
+		{ParseOptions{Profile: GoSemVer}, "v1.2.3.40+build", 7, "extra"},
+		{ParseOptions{ComponentMax: 10}, "v1.11", 3, "minor"},
+	} {
+		_, err := tc.opts.Parse(tc.input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: should be *ParseError but is %#v", tc.input, err)
+			continue
+		}
+		if parseErr.Input != tc.input || parseErr.Offset != tc.offset || parseErr.Component != tc.component {
+			t.Errorf(
+				"%q: not equal:\nexpected = offset %d, component %q\nactual = offset %d, component %q (%v)",
+				tc.input, tc.offset, tc.component, parseErr.Offset, parseErr.Component, err,
+			)
+		}
+		if parseErr.Reason == "" {
+			t.Errorf("%q: empty reason", tc.input)
+		}
+	}
+
+	_, err := ParseCore("1.2.3-rc.1")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Offset != 5 {
+		t.Errorf("should be *ParseError at offset 5 but is %#v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package exver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	sortKeyMaxIdents   = 7
+	sortKeyMaxIdentLen = 31
+	sortKeyCoreLen     = 16
+	sortKeyIdentLen    = 1 + sortKeyMaxIdentLen
+	// SortKeyLen is the length of strings returned from [Version.SortKey].
+	// It fits in VARCHAR(255).
+	SortKeyLen = sortKeyCoreLen + 1 + sortKeyMaxIdents*sortKeyIdentLen + 1
+)
+
+const (
+	sortKeyPreRelease   = '-'
+	sortKeyNoPreRelease = '~'
+
+	sortKeyIdentAbsent  = '0'
+	sortKeyIdentNumeric = '1'
+	sortKeyIdentAlnum   = '2'
+
+	// sortKeyPad pads alphanumeric identifiers at right.
+	// It must be less than any identifier character, the least of which is '-'.
+	sortKeyPad = '!'
+)
+
+// ErrSortKeyLimit is returned from [Version.SortKey]
+// when the pre-release of the version does not fit in the sort key.
+var ErrSortKeyLimit = errors.New("exceeding sort key limit")
+
+// SortKey returns a fixed-width string of [SortKeyLen] bytes that encodes v
+// so that comparing sort keys byte-wise yields exactly the same ordering as [Version.Compare].
+// It is meant to be stored in databases, as a column ordered by binary collation.
+// [ParseSortKey] decodes the key back to Version.
+//
+// The key encodes the core, including the number of components, and the pre-release of v.
+// v-prefix and build-meta are not encoded, as they do not affect ordering.
+//
+// The pre-release must have at most 7 dot-separated identifiers,
+// each of which is limited at maximum of 31 letters.
+// Otherwise SortKey returns an error wrapping [ErrSortKeyLimit].
+func (v Version) SortKey() (string, error) {
+	var builder strings.Builder
+	builder.Grow(SortKeyLen)
+
+	for i := range 4 {
+		s := strconv.FormatUint(uint64(v.core.component[i]), 10)
+		for range 4 - len(s) {
+			builder.WriteByte('0')
+		}
+		builder.WriteString(s)
+	}
+
+	if v.prerelease == "" {
+		builder.WriteByte(sortKeyNoPreRelease)
+	} else {
+		builder.WriteByte(sortKeyPreRelease)
+	}
+
+	if err := writeSortableIdents(&builder, v.prerelease, sortKeyMaxIdents, false); err != nil {
+		return "", err
+	}
+
+	// The number of components is compared only when cores and pre-releases are equal.
+	builder.WriteByte(byte('0' + v.core.length))
+
+	return builder.String(), nil
+}
+
+// writeSortableIdents writes dot-separated identifiers of pre into slots fixed-width slots,
+// each of which is a kind byte followed by [sortKeyMaxIdentLen] bytes,
+// so that the written string sorts as pre-releases are compared.
+// If truncate is true, identifiers exceeding limits are truncated, otherwise an error wrapping [ErrSortKeyLimit] is returned.
+func writeSortableIdents(builder *strings.Builder, pre string, slots int, truncate bool) error {
+	var (
+		i     int
+		ident string
+	)
+	for i = 0; pre != "" && i < slots; i++ {
+		ident, pre, _ = strings.Cut(pre, ".")
+		if len(ident) > sortKeyMaxIdentLen {
+			if !truncate {
+				return fmt.Errorf("%w: pre-release identifier %q is longer than %d", ErrSortKeyLimit, ident, sortKeyMaxIdentLen)
+			}
+			ident = ident[:sortKeyMaxIdentLen]
+		}
+		// Numeric identifiers are compared numerically and precede alphanumeric ones.
+		if isNum(ident) {
+			builder.WriteByte(sortKeyIdentNumeric)
+			for range sortKeyMaxIdentLen - len(ident) {
+				builder.WriteByte('0')
+			}
+			builder.WriteString(ident)
+		} else {
+			builder.WriteByte(sortKeyIdentAlnum)
+			builder.WriteString(ident)
+			for range sortKeyMaxIdentLen - len(ident) {
+				builder.WriteByte(sortKeyPad)
+			}
+		}
+	}
+	if pre != "" && !truncate {
+		return fmt.Errorf("%w: pre-release has more than %d identifiers", ErrSortKeyLimit, slots)
+	}
+	// A larger set of identifiers has a higher precedence if all of the preceding identifiers are equal.
+	for ; i < slots; i++ {
+		builder.WriteByte(sortKeyIdentAbsent)
+		for range sortKeyMaxIdentLen {
+			builder.WriteByte('0')
+		}
+	}
+	return nil
+}
+
+// ParseSortKey decodes key, a string returned from [Version.SortKey], into Version.
+// The returned Version has neither v-prefix nor build-meta.
+func ParseSortKey(key string) (Version, error) {
+	if len(key) != SortKeyLen {
+		return Version{}, fmt.Errorf("sort key must be %d bytes long but is %d", SortKeyLen, len(key))
+	}
+
+	var v Version
+	for i := range 4 {
+		s := key[i*4 : i*4+4]
+		if !isNum(s) {
+			return Version{}, fmt.Errorf("invalid %q in sort key", componentName(i))
+		}
+		n, _ := strconv.ParseUint(s, 10, 16)
+		if n > componentMax {
+			return Version{}, fmt.Errorf("%q too large: larger than %d", componentName(i), componentMax)
+		}
+		v.core.component[i] = uint16(n)
+	}
+
+	length := key[len(key)-1]
+	if length < '0' || '4' < length {
+		return Version{}, fmt.Errorf("invalid length %q in sort key", length)
+	}
+	v.core.length = int(length - '0')
+	for i := v.core.length; i < 4; i++ {
+		if v.core.component[i] != 0 {
+			return Version{}, fmt.Errorf("non-zero %q beyond length %d", componentName(i), v.core.length)
+		}
+	}
+
+	rest := key[sortKeyCoreLen+1 : len(key)-1]
+	switch key[sortKeyCoreLen] {
+	case sortKeyNoPreRelease:
+		if strings.Trim(rest, "0") != "" {
+			return Version{}, fmt.Errorf("pre-release identifiers in sort key without pre-release")
+		}
+		return v, nil
+	case sortKeyPreRelease:
+	default:
+		return Version{}, fmt.Errorf("invalid pre-release marker %q in sort key", key[sortKeyCoreLen])
+	}
+
+	var (
+		builder strings.Builder
+		absent  bool
+	)
+	for i := range sortKeyMaxIdents {
+		slot := rest[i*sortKeyIdentLen : (i+1)*sortKeyIdentLen]
+		kind, ident := slot[0], slot[1:]
+		if absent && kind != sortKeyIdentAbsent {
+			return Version{}, fmt.Errorf("pre-release identifier after absent one in sort key")
+		}
+		switch kind {
+		case sortKeyIdentAbsent:
+			if strings.Trim(ident, "0") != "" {
+				return Version{}, fmt.Errorf("invalid absent pre-release identifier in sort key")
+			}
+			absent = true
+			continue
+		case sortKeyIdentNumeric:
+			if !isNum(ident) {
+				return Version{}, fmt.Errorf("invalid numeric pre-release identifier %q in sort key", ident)
+			}
+			ident = strings.TrimLeft(ident, "0")
+			if ident == "" {
+				ident = "0"
+			}
+		case sortKeyIdentAlnum:
+			ident = strings.TrimRight(ident, string(sortKeyPad))
+			if ident == "" || isNum(ident) {
+				return Version{}, fmt.Errorf("invalid alphanumeric pre-release identifier in sort key")
+			}
+		default:
+			return Version{}, fmt.Errorf("invalid pre-release identifier kind %q in sort key", kind)
+		}
+		if i > 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(ident)
+	}
+
+	if builder.Len() == 0 {
+		return Version{}, fmt.Errorf("empty pre-release in sort key")
+	}
+	if v.core.length < 3 {
+		return Version{}, fmt.Errorf("pre-release is only allowed for full or extended version")
+	}
+	pre := builder.String()
+	if validated, rest, ok := preRelease(pre); !ok || validated != pre || rest != "" {
+		return Version{}, fmt.Errorf("invalid pre-release %q in sort key", pre)
+	}
+	v.prerelease = pre
+	return v, nil
+}
@@ -0,0 +1,159 @@
+package exver
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSortKey(t *testing.T) {
+	ordered := []string{
+		"0",
+		"0.0.0-0",
+		"0.0.0",
+		"0.0.0.0",
+		"1.0.0-0",
+		"1.0.0-0.0",
+		"1.0.0-1",
+		"1.0.0-9",
+		"1.0.0-10",
+		"1.0.0-" + strings.Repeat("9", 31),
+		"1.0.0--",
+		"1.0.0-0a",
+		"1.0.0-A",
+		"1.0.0-a",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha-",
+		"1.0.0-alpha0",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0-rc.1.2.3.4.5.6",
+		"1.0.0-" + strings.Repeat("z", 31),
+		"1",
+		"1.0",
+		"v1.0.0+build",
+		"1.0.0.0",
+		"1.0.0.1-pre",
+		"1.0.0.1",
+		"1.0.1",
+		"9999.9999.9999.9999",
+	}
+	keys := make([]string, len(ordered))
+	for i, s := range ordered {
+		v := MustParse(s)
+		key, err := v.SortKey()
+		if err != nil {
+			t.Fatalf("%q: should be nil error but is %v", s, err)
+		}
+		if len(key) != SortKeyLen {
+			t.Errorf("%q: wrong length %d", s, len(key))
+		}
+		keys[i] = key
+
+		decoded, err := ParseSortKey(key)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", s, err)
+			continue
+		}
+		expected := v.WithV(false)
+		expected.build = ""
+		if decoded != expected {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", expected, decoded)
+		}
+	}
+	for i := range ordered {
+		for j := range ordered {
+			expected := MustParse(ordered[i]).Compare(MustParse(ordered[j]))
+			if actual := strings.Compare(keys[i], keys[j]); actual != expected {
+				t.Errorf("%q vs %q: not equal:\nexpected = %d\nactual = %d", ordered[i], ordered[j], expected, actual)
+			}
+		}
+	}
+	if SortKeyLen > 255 {
+		t.Errorf("SortKeyLen should fit in VARCHAR(255) but is %d", SortKeyLen)
+	}
+}
+
+func TestSortKey_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	idents := []string{"0", "1", "2", "10", "-", "a", "b", "alpha", "beta", "rc", "a1", "1a"}
+	randVersion := func() Version {
+		var builder strings.Builder
+		length := 1 + r.IntN(4)
+		for i := range length {
+			if i > 0 {
+				builder.WriteByte('.')
+			}
+			builder.WriteString(strconv.Itoa(r.IntN(3)))
+		}
+		if length >= 3 && r.IntN(2) == 0 {
+			builder.WriteByte('-')
+			for i := range 1 + r.IntN(sortKeyMaxIdents) {
+				if i > 0 {
+					builder.WriteByte('.')
+				}
+				builder.WriteString(idents[r.IntN(len(idents))])
+			}
+		}
+		return MustParse(builder.String())
+	}
+	for range 10_000 {
+		v, u := randVersion(), randVersion()
+		vk, _ := v.SortKey()
+		uk, _ := u.SortKey()
+		if expected, actual := v.Compare(u), strings.Compare(vk, uk); expected != actual {
+			t.Errorf("%q vs %q: not equal:\nexpected = %d\nactual = %d", v, u, expected, actual)
+		}
+	}
+}
+
+func TestSortKey_limit(t *testing.T) {
+	for _, input := range []string{
+		"1.0.0-1.2.3.4.5.6.7.8",
+		"1.0.0-" + strings.Repeat("a", 32),
+		"1.0.0-" + strings.Repeat("1", 32),
+	} {
+		_, err := MustParse(input).SortKey()
+		if !errors.Is(err, ErrSortKeyLimit) {
+			t.Errorf("%q: should be ErrSortKeyLimit but is %v", input, err)
+		}
+	}
+}
+
+func TestParseSortKey_invalid(t *testing.T) {
+	valid, _ := MustParse("1.2.3-alpha.1").SortKey()
+	replace := func(i int, s string) string {
+		return valid[:i] + s + valid[i+len(s):]
+	}
+	slot := func(i int) int {
+		return sortKeyCoreLen + 1 + i*sortKeyIdentLen
+	}
+	for _, input := range []string{
+		"",
+		valid[:len(valid)-1],
+		valid + "0",
+		replace(0, "a"),
+		replace(len(valid)-1, "5"),
+		replace(len(valid)-1, "2"),
+		replace(sortKeyCoreLen, "+"),
+		replace(sortKeyCoreLen, "~"),
+		replace(slot(0), "3"),
+		replace(slot(0), "1"),
+		replace(slot(0), "2!!"),
+		replace(slot(0), "0"),
+		replace(slot(1), "2"),
+		replace(slot(1), "0"),
+		replace(slot(2), "2"),
+	} {
+		if _, err := ParseSortKey(input); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", input)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package exver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+var (
+	_ driver.Valuer = Core{}
+	_ sql.Scanner   = (*Core)(nil)
+)
+
+// Value implements [driver.Valuer].
+// c is stored as its string representation.
+// Use [Core.Int64] instead if you need a numerically comparable column.
+func (c Core) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// Scan implements [sql.Scanner].
+// src can be either a string or []byte in the form of [ParseCore],
+// or an int64 produced by [Core.Int64], which is decoded by [CoreFromInt64].
+func (c *Core) Scan(src any) error {
+	var (
+		core Core
+		err  error
+	)
+	switch x := src.(type) {
+	case string:
+		core, err = ParseCore(x)
+	case []byte:
+		core, err = ParseCore(string(x))
+	case int64:
+		core, err = CoreFromInt64(x)
+	default:
+		return fmt.Errorf("cannot scan %T into Core", src)
+	}
+	if err != nil {
+		return err
+	}
+	*c = core
+	return nil
+}
+
+// CoreFromInt64 decodes i, a value returned from [Core.Int64], back into Core.
+// Since Int64 does not retain the number of components,
+// the returned Core has 4 components if extra is non-zero, 3 otherwise.
+func CoreFromInt64(i int64) (Core, error) {
+	if i < 0 || i > 9999_9999_9999_9999 {
+		return Core{}, fmt.Errorf("%d is out of range", i)
+	}
+	var c Core
+	for idx := 3; idx >= 0; idx-- {
+		c.component[idx] = uint16(i % 10000)
+		i /= 10000
+	}
+	c.length = 3
+	if c.component[3] != 0 {
+		c.length = 4
+	}
+	return c, nil
+}
+
+var (
+	_ driver.Valuer = Version{}
+	_ sql.Scanner   = (*Version)(nil)
+)
+
+// Value implements [driver.Valuer].
+// v is stored as its string representation.
+// Use [Version.SortKey] instead if you need a column ordered as [Version.Compare].
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// Scan implements [sql.Scanner].
+// src must be either a string or []byte in the form of [Parse].
+func (v *Version) Scan(src any) error {
+	switch x := src.(type) {
+	case string:
+		return v.UnmarshalText([]byte(x))
+	case []byte:
+		return v.UnmarshalText(x)
+	default:
+		return fmt.Errorf("cannot scan %T into Version", src)
+	}
+}
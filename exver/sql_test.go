@@ -0,0 +1,74 @@
+package exver
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestCore_sql(t *testing.T) {
+	c := MustParseCore("1.2.3")
+	value, err := c.Value()
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if value != driver.Value("1.2.3") {
+		t.Errorf("not equal:\nexpected = %s\nactual = %v", "1.2.3", value)
+	}
+
+	for _, tc := range []struct {
+		src      any
+		expected Core
+	}{
+		{"1.2.3", MustParseCore("1.2.3")},
+		{[]byte("1.2"), MustParseCore("1.2")},
+		{MustParseCore("1.2.3").Int64(), MustParseCore("1.2.3")},
+		{MustParseCore("1.2.3.4").Int64(), MustParseCore("1.2.3.4")},
+		{MustParseCore("9999.9999.9999.9999").Int64(), MustParseCore("9999.9999.9999.9999")},
+		{int64(0), MustParseCore("0.0.0")},
+	} {
+		var scanned Core
+		if err := scanned.Scan(tc.src); err != nil {
+			t.Errorf("%v: should be nil error but is %v", tc.src, err)
+			continue
+		}
+		if scanned != tc.expected {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.expected, scanned)
+		}
+	}
+
+	for _, src := range []any{nil, 1.2, "1.2.3-pre", int64(-1), int64(1_0000_0000_0000_0000)} {
+		var scanned Core
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("%v: should be non-nil error but nil", src)
+		}
+	}
+}
+
+func TestVersion_sql(t *testing.T) {
+	v := MustParse("v1.2.3-rc.1+build")
+	value, err := v.Value()
+	if err != nil {
+		t.Fatalf("should be nil error but is %v", err)
+	}
+	if value != driver.Value("v1.2.3-rc.1+build") {
+		t.Errorf("not equal:\nexpected = %s\nactual = %v", "v1.2.3-rc.1+build", value)
+	}
+
+	for _, src := range []any{value, []byte("v1.2.3-rc.1+build")} {
+		var scanned Version
+		if err := scanned.Scan(src); err != nil {
+			t.Errorf("%v: should be nil error but is %v", src, err)
+			continue
+		}
+		if scanned != v {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", v, scanned)
+		}
+	}
+
+	for _, src := range []any{nil, int64(1), "v", "1.2-pre"} {
+		var scanned Version
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("%v: should be non-nil error but nil", src)
+		}
+	}
+}
@@ -63,25 +63,28 @@ func MustNewCore(nums []uint16) Core {
 // s must be dot-separated numeric values without leading zeros.
 // Same rules to [NewCore] apply here:
 // the number of version fields must be in between 1 to 4 and each version component must not be greater than 9999.
+// If s is not accepted, it returns a *[ParseError].
 func ParseCore(s string) (Core, error) {
-	a, b, c, d, s, err := version(s)
+	a, b, c, d, rest, err := version(s, componentMax)
 	if err != nil {
+		err.(*ParseError).Input = s
 		return Core{}, err
 	}
 
-	core, err := convertCore(a, b, c, d)
-	if err != nil {
-		return core, err
-	}
-
-	if len(s) > 0 {
-		return Core{}, fmt.Errorf("extra string %q after version core", s)
+	if len(rest) > 0 {
+		return Core{}, &ParseError{
+			Input:  s,
+			Offset: len(s) - len(rest),
+			Reason: fmt.Sprintf("extra string %q after version core", rest),
+		}
 	}
 
-	return core, nil
+	return convertCore(a, b, c, d), nil
 }
 
-func convertCore(a, b, c, d int64) (Core, error) {
+// convertCore converts components returned from version into Core.
+// Negative values mean missing components.
+func convertCore(a, b, c, d int64) Core {
 	rawComponent := [4]int64{a, b, c, d}
 	var comopnent [4]uint16
 	var i int
@@ -91,13 +94,10 @@ func convertCore(a, b, c, d int64) (Core, error) {
 		if rawComponent[i] < 0 {
 			break
 		}
-		if rawComponent[i] > componentMax {
-			return Core{}, fmt.Errorf("%q too large: larger than %d", componentName(i), componentMax)
-		}
 		comopnent[i] = uint16(rawComponent[i])
 	}
 
-	return Core{component: comopnent, length: i}, nil
+	return Core{component: comopnent, length: i}
 }
 
 // MustParseCore is like [ParseCore] but panics if any error occurs.
@@ -270,23 +270,10 @@ type Version struct {
 }
 
 // Parse parses input string as Version.
+// If s is not accepted, it returns a *[ParseError].
+// Use [ParseOptions] for stricter grammars.
 func Parse(s string) (Version, error) {
-	v, a, b, c, d, pre_, build_, err := vPrefixedValidExtendedVer(s)
-	if err != nil {
-		return Version{}, err
-	}
-
-	core, err := convertCore(a, b, c, d)
-	if err != nil {
-		return Version{}, err
-	}
-
-	return Version{
-		vPrefix:    v,
-		core:       core,
-		prerelease: pre_,
-		build:      build_,
-	}, nil
+	return ParseOptions{}.Parse(s)
 }
 
 // MustParse is like [Parse] but panics when s is not accepted as the extended version string.
@@ -377,6 +364,12 @@ func (v Version) String() string {
 	return builder.String()
 }
 
+// Short is like [Version.String] but omits build-meta, e.g. v1.2.3-rc.1 for v1.2.3-rc.1+build.
+func (v Version) Short() string {
+	v.build = ""
+	return v.String()
+}
+
 // PreReleaseSortable returns pre-release string normalized so that it can be sorted simply by ascii order.
 //
 // The size of returned string is always fixed at 256 by laying following limitations;
@@ -384,8 +377,9 @@ func (v Version) String() string {
 //   - Dot-separated parts are limited at maximum of 8.
 //   - Each sperated part is limited at maximum of 31 letters.
 //
-// You can safely cut off the right-most character (v.PreReleaseSortable()[:255])
-// to fit it to 255 chars (for VARCHAR(255) fields.)
+// Cutting off the right-most character (v.PreReleaseSortable()[:255]) to fit it to 255 chars (for VARCHAR(255) fields)
+// keeps ordering only for pre-releases with at most 7 parts,
+// since the cut drops the last char of the 8th part.
 //
 // As per semantic version 2 spec, pre-release can be dot-separated ascii text.
 // Each sperated part is compared as like version components in version core.
@@ -395,6 +389,13 @@ func (v Version) String() string {
 //
 // PreReleaseSortable returns strings.Repeat("~", 256) if v is not with pre-release,
 // since as per the spec, version without pre-prelease is more than versions with that.
+//
+// The ordering diverges from [Version.Compare] for some pre-releases,
+// e.g. "a" and "a0" are encoded equally and "-" sorts before numeric parts.
+// The encoding is kept unchanged so that strings already stored keep sorting among themselves.
+//
+// Deprecated: use [Version.PreReleaseSortableV2], which follows [Version.Compare].
+// Its encoding differs, so stored strings must be regenerated before switching.
 func (v Version) PreReleaseSortable() string {
 	if v.prerelease == "" {
 		// version without pre-release is more than versions with pre-release.
@@ -441,6 +442,43 @@ func (v Version) PreReleaseSortable() string {
 	return builder.String()
 }
 
+// PreReleaseSortableV2 returns pre-release string normalized so that it can be sorted simply by ascii order.
+//
+// The size of returned string is always fixed at 256 by laying following limitations;
+//
+//   - Dot-separated parts are limited at maximum of 8.
+//   - Each sperated part is limited at maximum of 31 letters.
+//
+// Parts exceeding limits are dropped or truncated, thus ordering is not guaranteed for such pre-releases.
+// Cutting off the right-most character (v.PreReleaseSortableV2()[:255]) to fit it to 255 chars (for VARCHAR(255) fields)
+// keeps ordering only for pre-releases with at most 7 parts,
+// since the cut drops the last char of the 8th part.
+//
+// As per semantic version 2 spec, pre-release can be dot-separated ascii text.
+// Each sperated part is compared as like version components in version core.
+// Parts with only numeric value is compared as number, others as ascii text.
+// Numeric parts always precede others and a larger set of parts has a higher precedence if all the preceding parts are equal.
+//
+// To simulate the comparison rule, PreReleaseSortableV2 encodes each part into 32 chars:
+// a leading char telling the part is absent ('0'), numeric ('1') or not ('2'),
+// followed by the part padded at left with '0' if numeric, otherwise padded at right with '!'.
+//
+// PreReleaseSortableV2 returns strings.Repeat("~", 256) if v is not with pre-release,
+// since as per the spec, version without pre-prelease is more than versions with that.
+//
+// Unlike [Version.PreReleaseSortable], the result sorts exactly as [Version.Compare] orders pre-releases within the limits.
+// [Version.SortKey] encodes the core and the pre-release together in the same manner.
+func (v Version) PreReleaseSortableV2() string {
+	if v.prerelease == "" {
+		// version without pre-release is more than versions with pre-release.
+		return strings.Repeat("~", 256)
+	}
+	var builder strings.Builder
+	builder.Grow(256)
+	_ = writeSortableIdents(&builder, v.prerelease, 8, true)
+	return builder.String()
+}
+
 var (
 	_ encoding.TextMarshaler   = Version{}
 	_ encoding.TextUnmarshaler = (*Version)(nil)
@@ -584,12 +622,15 @@ func takeString(s string, cond func(r rune) bool) (took, rest string, ok bool) {
 // <v prefixed valid extended ver> ::= "v" <valid extended ver>
 //
 //	| <valid extended ver>
-func vPrefixedValidExtendedVer(s string) (v bool, a, b, c, d int64, pre_, build_ string, err error) {
+func vPrefixedValidExtendedVer(s string, max uint64) (v bool, a, b, c, d int64, pre_, build_ string, err error) {
 	if len(s) > 0 && s[0] == 'v' {
 		v = true
 		s = s[1:]
 	}
-	a, b, c, d, pre_, build_, err = validExtendedVer(s)
+	a, b, c, d, pre_, build_, err = validExtendedVer(s, max)
+	if err != nil && v {
+		err.(*ParseError).Offset++
+	}
 	return
 }
 
@@ -600,52 +641,59 @@ func vPrefixedValidExtendedVer(s string) (v bool, a, b, c, d int64, pre_, build_
 //	| <full version core> "-" <pre-release>
 //	| <full version core> "+" <build>
 //	| <full version core> "-" <pre-release> "+" <build>
-func validExtendedVer(s string) (a, b, c, d int64, pre_, build_ string, err error) {
-	a, b, c, d, s, err = version(s)
+//
+// Errors returned from validExtendedVer are always *ParseError whose offset is relative to s.
+func validExtendedVer(s string, max uint64) (a, b, c, d int64, pre_, build_ string, err error) {
+	orig := s
+	a, b, c, d, s, err = version(s, max)
+	if err != nil {
+		return
+	}
 
 	var (
 		ok bool
 	)
 
 	if len(s) == 0 {
-		if a < 0 {
-			err = fmt.Errorf("empty string")
-		}
 		return
 	}
 
 	if c < 0 {
-		err = fmt.Errorf("missing patch")
+		err = &ParseError{Offset: len(orig) - len(s), Component: "patch", Reason: "missing before pre-release or build-meta"}
 		return
 	}
 
 	if s[0] != '-' && s[0] != '+' {
-		err = fmt.Errorf("extra string after version core")
+		err = &ParseError{Offset: len(orig) - len(s), Reason: fmt.Sprintf("extra string %q after version core", s)}
+		return
 	}
 
+	component := ""
 	if s[0] == '-' {
 		s = s[1:]
 		pre_, s, ok = preRelease(s)
 		if !ok {
-			err = fmt.Errorf("invalid \"pre-prelease\"")
+			err = &ParseError{Offset: len(orig) - len(s), Component: "pre-release", Reason: "invalid identifier"}
 			return
 		}
 		if len(s) == 0 {
 			return
 		}
+		component = "pre-release"
 	}
 
 	if s[0] == '+' {
 		s = s[1:]
 		build_, s, ok = build(s)
 		if !ok {
-			err = fmt.Errorf("invalid \"build\"")
+			err = &ParseError{Offset: len(orig) - len(s), Component: "build-meta", Reason: "invalid identifier"}
 			return
 		}
+		component = "build-meta"
 	}
 
 	if len(s) != 0 {
-		err = fmt.Errorf("extra string %q after extended ver", s)
+		err = &ParseError{Offset: len(orig) - len(s), Component: component, Reason: fmt.Sprintf("invalid character %q", s[0])}
 		return
 	}
 
@@ -670,9 +718,13 @@ func componentName(idx int) string {
 //	| <major> "." <minor>
 //	| <major> "." <minor> "." <patch>
 //	| <major> "." <minor> "." <patch> "." <extra>
-func version(s string) (a, b, c, d int64, rest string, err error) {
+//
+// Each component must not be greater than max.
+// Errors returned from version are always *ParseError whose offset is relative to s.
+func version(s string, max uint64) (a, b, c, d int64, rest string, err error) {
 	a, b, c, d = -1, -1, -1, -1
 	var (
+		orig   = s
 		num    string
 		parsed uint64
 		ok     bool
@@ -690,19 +742,28 @@ LOOP:
 			case '-', '+':
 				break LOOP
 			default:
-				err = fmt.Errorf("missing '.', '-' or '+' after %q", componentName(i-1))
+				err = &ParseError{
+					Offset:    len(orig) - len(s),
+					Component: componentName(i - 1),
+					Reason:    fmt.Sprintf("followed by %q instead of '.', '-' or '+'", s[0]),
+				}
 				return
 			}
 		}
 
+		offset := len(orig) - len(s)
 		num, s, ok = numericIdentifier(s)
 		if !ok {
-			err = fmt.Errorf("missing %q", componentName(i))
+			err = &ParseError{Offset: offset, Component: componentName(i), Reason: "missing"}
 			return
 		}
-		parsed, err = strconv.ParseUint(num, 10, 63)
-		if err != nil {
-			err = fmt.Errorf("parsing %q: %w", componentName(i), err)
+		if len(s) > 0 && digit(rune(s[0])) {
+			err = &ParseError{Offset: offset, Component: componentName(i), Reason: "leading zero"}
+			return
+		}
+		parsed, err = strconv.ParseUint(num, 10, 64)
+		if err != nil || parsed > max {
+			err = &ParseError{Offset: offset, Component: componentName(i), Reason: fmt.Sprintf("too large: larger than %d", max)}
 			return
 		}
 		switch i {
@@ -740,23 +801,7 @@ func preRelease(s string) (ident, rest string, ok bool) {
 //
 //	| <pre-release identifier> "." <dot-separated pre-release identifiers>
 func dotSeparatedPreReleaseIdentifiers(s string) (ident, rest string, ok bool) {
-	rest = s
-	var leng int
-	for len(rest) > 0 {
-		ident, rest, ok = preReleaseIdentifier(rest)
-		leng += len(ident)
-		if !ok {
-			return "", s, false
-		}
-		if len(rest) > 0 {
-			if rest[0] != '.' {
-				break
-			}
-			leng += 1
-			rest = rest[1:]
-		}
-	}
-	return s[:leng], rest, true
+	return dotSeparated(s, preReleaseIdentifier)
 }
 
 // <build> ::= <dot-separated build identifiers>
@@ -768,23 +813,23 @@ func build(s string) (ident, rest string, ok bool) {
 //
 //	| <build identifier> "." <dot-separated build identifiers>
 func dotSeparatedBuildIdentifiers(s string) (ident, rest string, ok bool) {
+	return dotSeparated(s, buildIdentidiers)
+}
+
+// dotSeparated takes identifiers accepted by identifier separated by '.'.
+// At least one identifier is required, and '.' must be followed by another identifier.
+func dotSeparated(s string, identifier func(s string) (ident, rest string, ok bool)) (idents, rest string, ok bool) {
 	rest = s
-	var leng int
-	for len(rest) > 0 {
-		ident, rest, ok = buildIdentidiers(rest)
-		leng += len(ident)
+	for {
+		_, rest, ok = identifier(rest)
 		if !ok {
 			return "", s, false
 		}
-		if len(rest) > 0 {
-			if rest[0] != '.' {
-				break
-			}
-			leng += 1
-			rest = rest[1:]
+		if len(rest) == 0 || rest[0] != '.' {
+			return s[:len(s)-len(rest)], rest, true
 		}
+		rest = rest[1:]
 	}
-	return s[:leng], rest, true
 }
 
 // <pre-release identifier> ::= <alphanumeric identifier>
@@ -815,24 +860,12 @@ func buildIdentidiers(s string) (ident, rest string, ok bool) {
 //	| <identifier characters> <non-digit>
 //	| <identifier characters> <non-digit> <identifier characters>
 func alphanumericIdentifier(s string) (ident, rest string, ok bool) {
-	if len(s) >= 2 {
-		if nonDigit(rune(s[0])) {
-			_idents, rest, ok := identifierCharacters(s[1:])
-			if ok {
-				return s[:len(_idents)+1], rest, true
-			}
-			return s[:1], s[1:], true
-		}
-		if _chars, rest, ok := identifierCharacters(s); ok {
-			// As per spec, identifier characters contains non-digit.
-			// So...basically last 2 lines can be coalesced to <identifier characters> I guess?
-			return _chars, rest, true
-		}
-	}
-	if len(s) >= 1 && nonDigit(rune(s[0])) {
-		return s[:1], s[1:], true
+	// All forms above are equivalent to <identifier characters> containing at least one <non-digit>.
+	chars, rest, ok := identifierCharacters(s)
+	if !ok || strings.IndexFunc(chars, nonDigit) < 0 {
+		return "", s, false
 	}
-	return "", s, false
+	return chars, rest, true
 }
 
 // <numeric identifier> ::= "0"
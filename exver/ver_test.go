@@ -444,37 +444,45 @@ func TestCompare(t *testing.T) {
 }
 
 func Test_compare_by_sortable_string(t *testing.T) {
-	for i, ti := range tests {
-		if ti.out == nil {
-			continue
-		}
-
-		if len(ti.out.PreReleaseSortable()) != 256 {
-			t.Errorf("wrong leng: expected = 256, actual = %s", ti.out.PreReleaseSortable())
-		}
-
-		for j, tj := range tests {
-			if tj.out == nil {
+	for _, sortable := range []struct {
+		name string
+		fn   func(Version) string
+	}{
+		{"PreReleaseSortable", Version.PreReleaseSortable},
+		{"PreReleaseSortableV2", Version.PreReleaseSortableV2},
+	} {
+		for i, ti := range tests {
+			if ti.out == nil {
 				continue
 			}
-			if ti.out.Core().Len() != tj.out.Core().Len() {
-				continue
+
+			if len(sortable.fn(*ti.out)) != 256 {
+				t.Errorf("%s: wrong leng: expected = 256, actual = %s", sortable.name, sortable.fn(*ti.out))
 			}
 
-			l := fmt.Sprintf("%016d_%s", ti.out.Core().Int64(), ti.out.PreReleaseSortable())
-			r := fmt.Sprintf("%016d_%s", tj.out.Core().Int64(), tj.out.PreReleaseSortable())
+			for j, tj := range tests {
+				if tj.out == nil {
+					continue
+				}
+				if ti.out.Core().Len() != tj.out.Core().Len() {
+					continue
+				}
 
-			c := cmp.Compare(l, r)
-			var want int
-			if ignoreMeta(*ti.out) == ignoreMeta(*tj.out) {
-				want = 0
-			} else if i < j {
-				want = -1
-			} else {
-				want = +1
-			}
-			if c != want {
-				t.Errorf("Compare(%q, %q) = %d, want %d\nl = %s\nr = %s", ti.in, tj.in, c, want, l, r)
+				l := fmt.Sprintf("%016d_%s", ti.out.Core().Int64(), sortable.fn(*ti.out))
+				r := fmt.Sprintf("%016d_%s", tj.out.Core().Int64(), sortable.fn(*tj.out))
+
+				c := cmp.Compare(l, r)
+				var want int
+				if ignoreMeta(*ti.out) == ignoreMeta(*tj.out) {
+					want = 0
+				} else if i < j {
+					want = -1
+				} else {
+					want = +1
+				}
+				if c != want {
+					t.Errorf("%s: Compare(%q, %q) = %d, want %d\nl = %s\nr = %s", sortable.name, ti.in, tj.in, c, want, l, r)
+				}
 			}
 		}
 	}
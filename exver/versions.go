@@ -0,0 +1,126 @@
+package exver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Versions is a list of [Version].
+type Versions []Version
+
+// Sort sorts vs in ascending order of [Version.Compare].
+// The sort is stable: versions comparing equal, e.g. ones differing only in build-meta, keep their order.
+func (vs Versions) Sort() {
+	slices.SortStableFunc(vs, Version.Compare)
+}
+
+// Latest returns the greatest version in vs for which filter returns true.
+// If filter is nil, all versions are considered.
+// ok is false if no version is found.
+// If several versions compare equal, the first one in vs is returned.
+func (vs Versions) Latest(filter func(v Version) bool) (latest Version, ok bool) {
+	for _, v := range vs {
+		if filter != nil && !filter(v) {
+			continue
+		}
+		if !ok || v.Compare(latest) > 0 {
+			latest, ok = v, true
+		}
+	}
+	return latest, ok
+}
+
+// LatestMatching returns the greatest version in vs which satisfies c.
+func (vs Versions) LatestMatching(c Constraint) (latest Version, ok bool) {
+	return vs.Latest(c.Check)
+}
+
+// GroupByMajor groups vs by major.
+// Keys of the returned map are Core having only major.
+// Versions in each group keep the order in vs.
+func (vs Versions) GroupByMajor() map[Core]Versions {
+	return vs.groupBy(1)
+}
+
+// GroupByMinor groups vs by major and minor.
+// Keys of the returned map are Core having major and minor.
+// Versions in each group keep the order in vs.
+func (vs Versions) GroupByMinor() map[Core]Versions {
+	return vs.groupBy(2)
+}
+
+func (vs Versions) groupBy(length int) map[Core]Versions {
+	groups := make(map[Core]Versions)
+	for _, v := range vs {
+		key := Core{length: length}
+		copy(key.component[:length], v.core.component[:length])
+		groups[key] = append(groups[key], v)
+	}
+	return groups
+}
+
+// Dedup returns versions in vs with duplicates removed, keeping the first occurrence.
+// Versions are duplicates if they compare equal by [Version.Compare],
+// that is, they differ only in v-prefix or build-meta.
+// vs is not modified.
+func (vs Versions) Dedup() Versions {
+	seen := make(map[Version]struct{}, len(vs))
+	out := make(Versions, 0, len(vs))
+	for _, v := range vs {
+		key := v
+		key.vPrefix = false
+		key.build = ""
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// FormatTag returns a git tag for v under prefix, e.g. "subpkg/v1.2.3" for prefix "subpkg".
+// If prefix is empty, it returns v.String().
+func FormatTag(prefix string, v Version) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return v.String()
+	}
+	return prefix + "/" + v.String()
+}
+
+// ParseTag parses tag, a git tag formatted by [FormatTag] with prefix.
+// It returns an error if tag is not under prefix or the rest of tag is not a valid version.
+// Nested prefixes are not matched: "a/b/v1.2.3" is not a tag under "a".
+func ParseTag(prefix, tag string) (Version, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	rest := tag
+	if prefix != "" {
+		var ok bool
+		rest, ok = strings.CutPrefix(tag, prefix+"/")
+		if !ok {
+			return Version{}, fmt.Errorf("tag %q is not prefixed with %q", tag, prefix+"/")
+		}
+	}
+	v, err := Parse(rest)
+	if err != nil {
+		return Version{}, fmt.Errorf("tag %q: %w", tag, err)
+	}
+	return v, nil
+}
+
+// ParseTags parses tags under prefix by [ParseTag].
+// Tags which are not under prefix or not valid versions are silently skipped,
+// since repositories usually have tags for other modules or irrelevant tags.
+func ParseTags(prefix string, tags []string) Versions {
+	var vs Versions
+	for _, tag := range tags {
+		v, err := ParseTag(prefix, tag)
+		if err != nil {
+			continue
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
@@ -0,0 +1,151 @@
+package exver
+
+import (
+	"slices"
+	"testing"
+)
+
+func parseVersions(ss ...string) Versions {
+	vs := make(Versions, len(ss))
+	for i, s := range ss {
+		vs[i] = MustParse(s)
+	}
+	return vs
+}
+
+func versionStrings(vs Versions) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = v.String()
+	}
+	return out
+}
+
+func TestVersions_Sort(t *testing.T) {
+	vs := parseVersions("1.2.0", "v1.0.0+b", "1.0.0-rc.1", "2", "1.0.0+a", "1.10.0", "1.2.0.1")
+	vs.Sort()
+	expected := []string{"1.0.0-rc.1", "v1.0.0+b", "1.0.0+a", "1.2.0", "1.2.0.1", "1.10.0", "2"}
+	if actual := versionStrings(vs); !slices.Equal(expected, actual) {
+		t.Errorf("not equal:\nexpected = %v\nactual = %v", expected, actual)
+	}
+}
+
+func TestVersions_Latest(t *testing.T) {
+	vs := parseVersions("1.2.3", "1.3.0-rc.1", "v1.2.10+a", "1.2.10+b", "2.0.0-alpha", "1.2.9")
+
+	latest, ok := vs.Latest(nil)
+	if !ok || latest.String() != "2.0.0-alpha" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "2.0.0-alpha", latest)
+	}
+
+	latest, ok = vs.Latest(func(v Version) bool { return v.PreRelease() == "" })
+	if !ok || latest.String() != "v1.2.10+a" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "v1.2.10+a", latest)
+	}
+
+	latest, ok = vs.LatestMatching(MustParseConstraint("~1.2"))
+	if !ok || latest.String() != "v1.2.10+a" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "v1.2.10+a", latest)
+	}
+
+	latest, ok = vs.LatestMatching(MustParseConstraint("<1.2.9"))
+	if !ok || latest.String() != "1.2.3" {
+		t.Errorf("not equal:\nexpected = %s\nactual = %s", "1.2.3", latest)
+	}
+
+	if _, ok := vs.LatestMatching(MustParseConstraint(">=3")); ok {
+		t.Errorf("should not be found")
+	}
+	if _, ok := Versions(nil).Latest(nil); ok {
+		t.Errorf("should not be found")
+	}
+}
+
+func TestVersions_GroupBy(t *testing.T) {
+	vs := parseVersions("1.2.3", "2.0.0", "1.3.0", "v1.2.4", "2.0.1-pre", "1")
+
+	byMajor := vs.GroupByMajor()
+	if len(byMajor) != 2 {
+		t.Errorf("wrong length: %v", byMajor)
+	}
+	for key, expected := range map[string][]string{
+		"1": {"1.2.3", "1.3.0", "v1.2.4", "1"},
+		"2": {"2.0.0", "2.0.1-pre"},
+	} {
+		if actual := versionStrings(byMajor[MustParseCore(key)]); !slices.Equal(expected, actual) {
+			t.Errorf("%s: not equal:\nexpected = %v\nactual = %v", key, expected, actual)
+		}
+	}
+
+	byMinor := vs.GroupByMinor()
+	if len(byMinor) != 4 {
+		t.Errorf("wrong length: %v", byMinor)
+	}
+	for key, expected := range map[string][]string{
+		"1.0": {"1"},
+		"1.2": {"1.2.3", "v1.2.4"},
+		"1.3": {"1.3.0"},
+		"2.0": {"2.0.0", "2.0.1-pre"},
+	} {
+		if actual := versionStrings(byMinor[MustParseCore(key)]); !slices.Equal(expected, actual) {
+			t.Errorf("%s: not equal:\nexpected = %v\nactual = %v", key, expected, actual)
+		}
+	}
+}
+
+func TestVersions_Dedup(t *testing.T) {
+	vs := parseVersions("1.2.3+a", "v1.2.3+b", "1.2.3", "1.2.3-rc.1", "1.2", "1.2.3-rc.1+c", "v1.2")
+	expected := []string{"1.2.3+a", "1.2.3-rc.1", "1.2"}
+	if actual := versionStrings(vs.Dedup()); !slices.Equal(expected, actual) {
+		t.Errorf("not equal:\nexpected = %v\nactual = %v", expected, actual)
+	}
+	if len(vs) != 7 || vs[1].String() != "v1.2.3+b" {
+		t.Errorf("input is modified: %v", vs)
+	}
+}
+
+func TestTag(t *testing.T) {
+	for _, tc := range []struct {
+		prefix, tag, version string
+	}{
+		{"", "v1.2.3", "v1.2.3"},
+		{"subpkg", "subpkg/v1.2.3", "v1.2.3"},
+		{"subpkg/", "subpkg/v1.2.3-rc.1", "v1.2.3-rc.1"},
+		{"a/b", "a/b/v0.1.0", "v0.1.0"},
+	} {
+		v, err := ParseTag(tc.prefix, tc.tag)
+		if err != nil {
+			t.Errorf("%q: should be nil error but is %v", tc.tag, err)
+			continue
+		}
+		if v.String() != tc.version {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.version, v)
+		}
+		if tag := FormatTag(tc.prefix, v); tag != tc.tag {
+			t.Errorf("not equal:\nexpected = %s\nactual = %s", tc.tag, tag)
+		}
+	}
+
+	for _, tc := range [][2]string{
+		{"", "subpkg/v1.2.3"},
+		{"subpkg", "v1.2.3"},
+		{"subpkg", "other/v1.2.3"},
+		{"subpkg", "subpkgv1.2.3"},
+		{"a", "a/b/v1.2.3"},
+		{"subpkg", "subpkg/latest"},
+	} {
+		if _, err := ParseTag(tc[0], tc[1]); err == nil {
+			t.Errorf("%q: should be non-nil error but nil", tc[1])
+		}
+	}
+
+	tags := []string{"v1.0.0", "sub/v0.1.0", "sub/v0.2.0", "sub/nightly", "sub/inner/v9.0.0", "sub/v0.2.1-rc.1"}
+	expected := []string{"v0.1.0", "v0.2.0", "v0.2.1-rc.1"}
+	if actual := versionStrings(ParseTags("sub", tags)); !slices.Equal(expected, actual) {
+		t.Errorf("not equal:\nexpected = %v\nactual = %v", expected, actual)
+	}
+	expected = []string{"v1.0.0"}
+	if actual := versionStrings(ParseTags("", tags)); !slices.Equal(expected, actual) {
+		t.Errorf("not equal:\nexpected = %v\nactual = %v", expected, actual)
+	}
+}